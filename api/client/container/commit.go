@@ -19,6 +19,7 @@ type commitOptions struct {
 	reference string
 
 	pause   bool
+	squash  bool
 	comment string
 	author  string
 	changes dockeropts.ListOpts
@@ -46,6 +47,7 @@ func NewCommitCommand(dockerCli *client.DockerCli) *cobra.Command {
 	flags.SetInterspersed(false)
 
 	flags.BoolVarP(&opts.pause, "pause", "p", true, "Pause container during commit")
+	flags.BoolVar(&opts.squash, "squash", false, "Squash the layers added on top of the base image into a single new layer")
 	flags.StringVarP(&opts.comment, "message", "m", "", "Commit message")
 	flags.StringVarP(&opts.author, "author", "a", "", "Author (e.g., \"John Hannibal Smith <hannibal@a-team.com>\")")
 
@@ -79,6 +81,7 @@ func runCommit(dockerCli *client.DockerCli, opts *commitOptions) error {
 		Author:    opts.author,
 		Changes:   opts.changes.GetAll(),
 		Pause:     opts.pause,
+		Squash:    opts.squash,
 		Config:    config,
 	}
 
@@ -55,6 +55,7 @@ type buildOptions struct {
 	rm             bool
 	forceRm        bool
 	pull           bool
+	squash         bool
 }
 
 // NewBuildCommand creates a new `docker build` command
@@ -99,6 +100,7 @@ func NewBuildCommand(dockerCli *client.DockerCli) *cobra.Command {
 	flags.BoolVar(&options.forceRm, "force-rm", false, "Always remove intermediate containers")
 	flags.BoolVarP(&options.quiet, "quiet", "q", false, "Suppress the build output and print image ID on success")
 	flags.BoolVar(&options.pull, "pull", false, "Always attempt to pull a newer version of the image")
+	flags.BoolVar(&options.squash, "squash", false, "Squash newly built layers into a single new layer")
 
 	client.AddTrustedFlags(flags, true)
 
@@ -253,6 +255,7 @@ func runBuild(dockerCli *client.DockerCli, options buildOptions) error {
 		Remove:         options.rm,
 		ForceRemove:    options.forceRm,
 		PullParent:     options.pull,
+		Squash:         options.squash,
 		Isolation:      container.Isolation(options.isolation),
 		CPUSetCPUs:     options.cpuSetCpus,
 		CPUSetMems:     options.cpuSetMems,
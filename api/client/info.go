@@ -60,6 +60,14 @@ func (cli *DockerCli) CmdInfo(args ...string) error {
 	fmt.Fprintf(cli.out, " Volume:")
 	fmt.Fprintf(cli.out, " %s", strings.Join(info.Plugins.Volume, " "))
 	fmt.Fprintf(cli.out, "\n")
+	if len(info.Plugins.VolumeHealth) != 0 {
+		fmt.Fprintf(cli.out, " Volume Health:\n")
+		for _, name := range info.Plugins.Volume {
+			if health, ok := info.Plugins.VolumeHealth[name]; ok {
+				fmt.Fprintf(cli.out, "  %s: %s\n", name, health)
+			}
+		}
+	}
 	fmt.Fprintf(cli.out, " Network:")
 	fmt.Fprintf(cli.out, " %s", strings.Join(info.Plugins.Network, " "))
 	fmt.Fprintf(cli.out, "\n")
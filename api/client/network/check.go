@@ -0,0 +1,63 @@
+package network
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"text/tabwriter"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/client"
+	"github.com/docker/docker/cli"
+	"github.com/spf13/cobra"
+)
+
+type checkOptions struct {
+	quiet bool
+}
+
+func newCheckCommand(dockerCli *client.DockerCli) *cobra.Command {
+	var opts checkOptions
+
+	cmd := &cobra.Command{
+		Use:   "check [OPTIONS] [IP:]PORT[-END][/PROTO] [[IP:]PORT[-END][/PROTO]...]",
+		Short: "Check whether host ports are available to publish",
+		Args:  cli.RequiresMinArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCheck(dockerCli, opts, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Only display the numbers of the available ports")
+
+	return cmd
+}
+
+func runCheck(dockerCli *client.DockerCli, opts checkOptions, ports []string) error {
+	results, err := dockerCli.Client().NetworkPortCheck(context.Background(), ports)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(dockerCli.Out(), 20, 1, 3, ' ', 0)
+	if !opts.quiet {
+		fmt.Fprintf(w, "PORT\tAVAILABLE\tUSED BY\n")
+	}
+	for _, r := range results {
+		if opts.quiet {
+			if r.Available {
+				fmt.Fprintln(w, r.PublicPort)
+			}
+			continue
+		}
+		usedBy := r.ContainerName
+		if usedBy == "" {
+			usedBy = r.Reason
+		}
+		fmt.Fprintf(w, "%s/%s\t%t\t%s\n", net.JoinHostPort(r.IP, strconv.Itoa(r.PublicPort)), r.Type, r.Available, usedBy)
+	}
+	w.Flush()
+	return nil
+}
@@ -20,12 +20,14 @@ func NewNetworkCommand(dockerCli *client.DockerCli) *cobra.Command {
 		},
 	}
 	cmd.AddCommand(
+		newCheckCommand(dockerCli),
 		newConnectCommand(dockerCli),
 		newCreateCommand(dockerCli),
 		newDisconnectCommand(dockerCli),
 		newInspectCommand(dockerCli),
 		newListCommand(dockerCli),
 		newRemoveCommand(dockerCli),
+		newUsageCommand(dockerCli),
 	)
 	return cmd
 }
@@ -0,0 +1,90 @@
+package network
+
+import (
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/client"
+	"github.com/docker/docker/cli"
+	"github.com/docker/engine-api/types"
+	"github.com/spf13/cobra"
+)
+
+type byPoolNetworkName []types.NetworkPoolUsage
+
+func (r byPoolNetworkName) Len() int           { return len(r) }
+func (r byPoolNetworkName) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+func (r byPoolNetworkName) Less(i, j int) bool { return r[i].Name < r[j].Name }
+
+type usageOptions struct {
+	ports bool
+}
+
+func newUsageCommand(dockerCli *client.DockerCli) *cobra.Command {
+	var opts usageOptions
+
+	cmd := &cobra.Command{
+		Use:   "usage [OPTIONS]",
+		Short: "Display the addresses and host ports in use",
+		Args:  cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUsage(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&opts.ports, "ports", false, "Display the host ports published by containers")
+
+	return cmd
+}
+
+func runUsage(dockerCli *client.DockerCli, opts usageOptions) error {
+	usage, err := dockerCli.Client().NetworkUsage(context.Background())
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(dockerCli.Out(), 20, 1, 3, ' ', 0)
+	if opts.ports {
+		fmt.Fprintf(w, "HOST IP\tHOST PORT\tPROTO\tCONTAINER\tCONTAINER PORT\n")
+		for _, p := range usage.Ports {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%d\n", p.IP, p.PublicPort, p.Type, p.ContainerName, p.PrivatePort)
+		}
+		w.Flush()
+		return nil
+	}
+
+	fmt.Fprintf(w, "NETWORK\tSUBNET\tADDRESS\tUSED BY\n")
+	sort.Sort(byPoolNetworkName(usage.Networks))
+	for _, n := range usage.Networks {
+		for _, pool := range n.Pools {
+			rows := 0
+			row := func(address, usedBy string) {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", n.Name, pool.Subnet, address, usedBy)
+				rows++
+			}
+			if pool.Gateway != "" {
+				row(pool.Gateway, "gateway")
+			}
+			names := make([]string, 0, len(pool.Reserved))
+			for name := range pool.Reserved {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				row(pool.Reserved[name], fmt.Sprintf("reserved (%s)", name))
+			}
+			for _, a := range pool.Allocated {
+				row(a.Address, a.Name)
+			}
+			if rows == 0 {
+				row("-", "-")
+			}
+		}
+	}
+	w.Flush()
+	return nil
+}
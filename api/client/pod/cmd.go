@@ -0,0 +1,46 @@
+package pod
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docker/docker/api/client"
+	"github.com/docker/docker/cli"
+)
+
+// NewPodCommand returns a cobra command for `pod` subcommands
+func NewPodCommand(dockerCli *client.DockerCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pod COMMAND",
+		Short: "Manage pods",
+		Long:  podDescription,
+		Args:  cli.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Fprintf(dockerCli.Err(), "\n"+cmd.UsageString())
+		},
+	}
+	cmd.AddCommand(
+		newCreateCommand(dockerCli),
+		newInspectCommand(dockerCli),
+		newListCommand(dockerCli),
+		newRemoveCommand(dockerCli),
+		newStartCommand(dockerCli),
+		newStopCommand(dockerCli),
+	)
+	return cmd
+}
+
+var podDescription = `
+The **docker pod** command has subcommands for managing pods. A pod is a group
+of containers sharing the network, IPC, PID and UTS namespaces of an infra
+container, which is created along with the pod.
+
+Create a container in a pod with the **--pod** flag of **docker create** and
+**docker run**. Starting a container of a pod starts its infra container.
+
+To see help for a subcommand, use:
+
+    docker pod CMD help
+
+`
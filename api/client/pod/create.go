@@ -0,0 +1,148 @@
+package pod
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/client"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/opts"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/docker/docker/reference"
+	"github.com/docker/docker/registry"
+	runconfigopts "github.com/docker/docker/runconfig/opts"
+	apiclient "github.com/docker/engine-api/client"
+	"github.com/docker/engine-api/types"
+	"github.com/docker/go-connections/nat"
+	"github.com/spf13/cobra"
+)
+
+// defaultInfraImage is the pause image, which is published for each
+// architecture.
+const defaultInfraImage = "gcr.io/google_containers/pause-%s:3.0"
+
+type createOptions struct {
+	name         string
+	labels       []string
+	infraImage   string
+	infraCommand string
+	publish      opts.ListOpts
+}
+
+func newCreateCommand(dockerCli *client.DockerCli) *cobra.Command {
+	opts := createOptions{
+		publish: opts.NewListOpts(nil),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "create [OPTIONS] [POD]",
+		Short: "Create a pod",
+		Long:  createDescription,
+		Args:  cli.RequiresMaxArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.name = args[0]
+			}
+			return runCreate(dockerCli, opts)
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringSliceVar(&opts.labels, "label", []string{}, "Set metadata for a pod")
+	flags.StringVar(&opts.infraImage, "infra-image", "", "Image of the infra container (default the pause image of the daemon architecture)")
+	flags.StringVar(&opts.infraCommand, "infra-command", "", "Command of the infra container")
+	flags.VarP(&opts.publish, "publish", "p", "Publish a port of the pod to the host")
+
+	return cmd
+}
+
+func runCreate(dockerCli *client.DockerCli, opts createOptions) error {
+	ctx := context.Background()
+
+	if opts.infraImage == "" {
+		// the daemon may not run on the architecture of the client
+		version, err := dockerCli.Client().ServerVersion(ctx)
+		if err != nil {
+			return err
+		}
+		opts.infraImage = fmt.Sprintf(defaultInfraImage, version.Arch)
+	}
+
+	_, portBindings, err := nat.ParsePortSpecs(opts.publish.GetAll())
+	if err != nil {
+		return err
+	}
+
+	req := types.PodCreateRequest{
+		Name:         opts.name,
+		Labels:       runconfigopts.ConvertKVStringsToMap(opts.labels),
+		InfraImage:   opts.infraImage,
+		InfraCommand: strings.Fields(opts.infraCommand),
+		PortBindings: portBindings,
+	}
+
+	resp, err := dockerCli.Client().PodCreate(ctx, req)
+	if err != nil {
+		if !apiclient.IsErrImageNotFound(err) {
+			return err
+		}
+		fmt.Fprintf(dockerCli.Err(), "Unable to find image '%s' locally\n", opts.infraImage)
+		if err := pullImage(ctx, dockerCli, opts.infraImage, dockerCli.Err()); err != nil {
+			return err
+		}
+		if resp, err = dockerCli.Client().PodCreate(ctx, req); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(dockerCli.Out(), "%s\n", resp.ID)
+	return nil
+}
+
+// pullImage pulls the infra image of a pod that is not present on the host.
+func pullImage(ctx context.Context, dockerCli *client.DockerCli, image string, out io.Writer) error {
+	ref, err := reference.ParseNamed(image)
+	if err != nil {
+		return err
+	}
+	repoInfo, err := registry.ParseRepositoryInfo(ref)
+	if err != nil {
+		return err
+	}
+
+	authConfig := dockerCli.ResolveAuthConfig(ctx, repoInfo.Index)
+	encodedAuth, err := client.EncodeAuthToBase64(authConfig)
+	if err != nil {
+		return err
+	}
+
+	responseBody, err := dockerCli.Client().ImageCreate(ctx, image, types.ImageCreateOptions{RegistryAuth: encodedAuth})
+	if err != nil {
+		return err
+	}
+	defer responseBody.Close()
+
+	return jsonmessage.DisplayJSONMessagesStream(responseBody, out, dockerCli.OutFd(), dockerCli.IsTerminalOut(), nil)
+}
+
+var createDescription = `
+Creates a new pod, along with its infra container. If a name is not specified,
+Docker generates a random name. The infra container is named after the pod,
+with an **-infra** suffix, and holds the namespaces the containers of the pod
+share. You create a pod and then create containers in it, for example:
+
+    $ docker pod create web
+    $ docker run -d --pod web nginx
+    $ docker run -d --pod web busybox wget -qO- localhost
+
+By default, the infra container runs the **pause** image of the architecture
+of the daemon, which does nothing but wait. Use **--infra-image** and **--infra-command** to run another image.
+
+The containers of a pod cannot publish ports themselves. Use **--publish** to
+publish the ports of the pod on the infra container:
+
+    $ docker pod create -p 8080:80 web
+
+`
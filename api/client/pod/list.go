@@ -0,0 +1,64 @@
+package pod
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/client"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/spf13/cobra"
+)
+
+type listOptions struct {
+	quiet   bool
+	noTrunc bool
+}
+
+func newListCommand(dockerCli *client.DockerCli) *cobra.Command {
+	var opts listOptions
+
+	cmd := &cobra.Command{
+		Use:     "ls [OPTIONS]",
+		Aliases: []string{"list"},
+		Short:   "List pods",
+		Args:    cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Only display pod IDs")
+	flags.BoolVar(&opts.noTrunc, "no-trunc", false, "Do not truncate the output")
+
+	return cmd
+}
+
+func runList(dockerCli *client.DockerCli, opts listOptions) error {
+	pods, err := dockerCli.Client().PodList(context.Background())
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(dockerCli.Out(), 20, 1, 3, ' ', 0)
+	if !opts.quiet {
+		fmt.Fprintf(w, "POD ID\tNAME\tRUNNING\tCONTAINERS\n")
+	}
+
+	for _, p := range pods {
+		ID := p.ID
+		if !opts.noTrunc {
+			ID = stringid.TruncateID(ID)
+		}
+		if opts.quiet {
+			fmt.Fprintln(w, ID)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%t\t%d\n", ID, p.Name, p.Running, len(p.Containers))
+	}
+	w.Flush()
+	return nil
+}
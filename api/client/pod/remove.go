@@ -0,0 +1,60 @@
+package pod
+
+import (
+	"fmt"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/client"
+	"github.com/docker/docker/cli"
+	"github.com/docker/engine-api/types"
+	"github.com/spf13/cobra"
+)
+
+type removeOptions struct {
+	force bool
+}
+
+func newRemoveCommand(dockerCli *client.DockerCli) *cobra.Command {
+	var opts removeOptions
+
+	cmd := &cobra.Command{
+		Use:     "rm [OPTIONS] POD [POD...]",
+		Aliases: []string{"remove"},
+		Short:   "Remove one or more pods",
+		Long:    removeDescription,
+		Args:    cli.RequiresMinArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRemove(dockerCli, opts, args)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Remove the containers of the pod")
+
+	return cmd
+}
+
+func runRemove(dockerCli *client.DockerCli, opts removeOptions, pods []string) error {
+	client := dockerCli.Client()
+	ctx := context.Background()
+	status := 0
+
+	for _, name := range pods {
+		if err := client.PodRemove(ctx, name, types.PodRemoveOptions{Force: opts.force}); err != nil {
+			fmt.Fprintf(dockerCli.Err(), "%s\n", err)
+			status = 1
+			continue
+		}
+		fmt.Fprintf(dockerCli.Out(), "%s\n", name)
+	}
+
+	if status != 0 {
+		return cli.StatusError{StatusCode: status}
+	}
+	return nil
+}
+
+var removeDescription = `
+Remove one or more pods, along with their infra containers. You cannot remove a
+pod that has containers, unless **--force** is set, which removes them too.
+`
@@ -0,0 +1,40 @@
+package pod
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/client"
+	"github.com/docker/docker/cli"
+	"github.com/spf13/cobra"
+)
+
+func newStartCommand(dockerCli *client.DockerCli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "start POD [POD...]",
+		Short: "Start one or more pods",
+		Args:  cli.RequiresMinArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStart(dockerCli, args)
+		},
+	}
+}
+
+func runStart(dockerCli *client.DockerCli, pods []string) error {
+	ctx := context.Background()
+
+	var errs []string
+	for _, pod := range pods {
+		if err := dockerCli.Client().PodStart(ctx, pod); err != nil {
+			errs = append(errs, err.Error())
+		} else {
+			fmt.Fprintf(dockerCli.Out(), "%s\n", pod)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package pod
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/client"
+	"github.com/docker/docker/cli"
+	"github.com/spf13/cobra"
+)
+
+type stopOptions struct {
+	time int
+}
+
+func newStopCommand(dockerCli *client.DockerCli) *cobra.Command {
+	var opts stopOptions
+
+	cmd := &cobra.Command{
+		Use:   "stop [OPTIONS] POD [POD...]",
+		Short: "Stop one or more pods",
+		Args:  cli.RequiresMinArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStop(dockerCli, opts, args)
+		},
+	}
+
+	cmd.Flags().IntVarP(&opts.time, "time", "t", 10, "Seconds to wait for stop before killing the containers")
+	return cmd
+}
+
+func runStop(dockerCli *client.DockerCli, opts stopOptions, pods []string) error {
+	ctx := context.Background()
+
+	var errs []string
+	for _, pod := range pods {
+		timeout := time.Duration(opts.time) * time.Second
+		if err := dockerCli.Client().PodStop(ctx, pod, &timeout); err != nil {
+			errs = append(errs, err.Error())
+		} else {
+			fmt.Fprintf(dockerCli.Out(), "%s\n", pod)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"golang.org/x/net/context"
 
 	Cli "github.com/docker/docker/cli"
+	dockeropts "github.com/docker/docker/opts"
 	flag "github.com/docker/docker/pkg/mflag"
 	"github.com/docker/docker/runconfig/opts"
 	"github.com/docker/engine-api/types/container"
@@ -29,6 +30,8 @@ func (cli *DockerCli) CmdUpdate(args ...string) error {
 	flMemorySwap := cmd.String([]string{"-memory-swap"}, "", "Swap limit equal to memory plus swap: '-1' to enable unlimited swap")
 	flKernelMemory := cmd.String([]string{"-kernel-memory"}, "", "Kernel memory limit")
 	flRestartPolicy := cmd.String([]string{"-restart"}, "", "Restart policy to apply when a container exits")
+	flNetworkRate := dockeropts.NewListOpts(nil)
+	cmd.Var(&flNetworkRate, []string{"-network-rate"}, "Limit the network rate (bytes per second), as ingress=<rate>, egress=<rate> or <rate> for both: '-1' to remove the limit")
 
 	cmd.Require(flag.Min, 1)
 	cmd.ParseFlags(args, true)
@@ -73,6 +76,11 @@ func (cli *DockerCli) CmdUpdate(args ...string) error {
 		}
 	}
 
+	networkIngressRate, networkEgressRate, err := opts.ParseNetworkRate(flNetworkRate.GetAll())
+	if err != nil {
+		return err
+	}
+
 	var restartPolicy container.RestartPolicy
 	if *flRestartPolicy != "" {
 		restartPolicy, err = opts.ParseRestartPolicy(*flRestartPolicy)
@@ -82,16 +90,18 @@ func (cli *DockerCli) CmdUpdate(args ...string) error {
 	}
 
 	resources := container.Resources{
-		BlkioWeight:       *flBlkioWeight,
-		CpusetCpus:        *flCpusetCpus,
-		CpusetMems:        *flCpusetMems,
-		CPUShares:         *flCPUShares,
-		Memory:            flMemory,
-		MemoryReservation: memoryReservation,
-		MemorySwap:        memorySwap,
-		KernelMemory:      kernelMemory,
-		CPUPeriod:         *flCPUPeriod,
-		CPUQuota:          *flCPUQuota,
+		BlkioWeight:        *flBlkioWeight,
+		CpusetCpus:         *flCpusetCpus,
+		CpusetMems:         *flCpusetMems,
+		CPUShares:          *flCPUShares,
+		Memory:             flMemory,
+		MemoryReservation:  memoryReservation,
+		MemorySwap:         memorySwap,
+		KernelMemory:       kernelMemory,
+		CPUPeriod:          *flCPUPeriod,
+		CPUQuota:           *flCPUQuota,
+		NetworkIngressRate: networkIngressRate,
+		NetworkEgressRate:  networkEgressRate,
 	}
 
 	updateConfig := container.UpdateConfig{
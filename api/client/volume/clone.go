@@ -0,0 +1,76 @@
+package volume
+
+import (
+	"fmt"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/client"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/opts"
+	runconfigopts "github.com/docker/docker/runconfig/opts"
+	"github.com/docker/engine-api/types"
+	"github.com/spf13/cobra"
+)
+
+type cloneOptions struct {
+	source     string
+	name       string
+	driverOpts opts.MapOpts
+	labels     []string
+}
+
+func newCloneCommand(dockerCli *client.DockerCli) *cobra.Command {
+	opts := cloneOptions{
+		driverOpts: *opts.NewMapOpts(nil, nil),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "clone [OPTIONS] SOURCE TARGET",
+		Short: "Create a volume with a copy of the data of another volume",
+		Long:  cloneDescription,
+		Args:  cli.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.source = args[0]
+			opts.name = args[1]
+			return runClone(dockerCli, cmd, opts)
+		},
+	}
+	flags := cmd.Flags()
+	flags.VarP(&opts.driverOpts, "opt", "o", "Set driver specific options")
+	flags.StringSliceVar(&opts.labels, "label", []string{}, "Set metadata for a volume")
+
+	return cmd
+}
+
+func runClone(dockerCli *client.DockerCli, cmd *cobra.Command, opts cloneOptions) error {
+	req := types.VolumeCloneRequest{
+		Name:       opts.name,
+		DriverOpts: opts.driverOpts.GetAll(),
+	}
+	if cmd.Flags().Changed("label") {
+		req.Labels = runconfigopts.ConvertKVStringsToMap(opts.labels)
+	}
+
+	vol, err := dockerCli.Client().VolumeClone(context.Background(), opts.source, req)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(dockerCli.Out(), "%s\n", vol.Name)
+	return nil
+}
+
+var cloneDescription = `
+Creates a new volume with the driver of the source volume, and copies the data
+of the source volume into it. Unless labels are given with **--label**, the new
+volume has the labels of the source volume. Driver options are not copied from
+the source volume; use **-o** or **--opt** to set them:
+
+    $ docker volume clone hello hello-copy
+    hello-copy
+
+The built-in **local** driver clones files with copy-on-write reflinks when the
+filesystem supports them, so the copy is fast and initially takes no space.
+
+`
@@ -21,10 +21,14 @@ func NewVolumeCommand(dockerCli *client.DockerCli) *cobra.Command {
 		},
 	}
 	cmd.AddCommand(
+		newCloneCommand(dockerCli),
 		newCreateCommand(dockerCli),
+		newExportCommand(dockerCli),
+		newImportCommand(dockerCli),
 		newInspectCommand(dockerCli),
 		newListCommand(dockerCli),
 		newRemoveCommand(dockerCli),
+		newUpdateCommand(dockerCli),
 	)
 	return cmd
 }
@@ -0,0 +1,63 @@
+package volume
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/client"
+	"github.com/docker/docker/cli"
+	"github.com/spf13/cobra"
+)
+
+type importOptions struct {
+	name  string
+	input string
+}
+
+func newImportCommand(dockerCli *client.DockerCli) *cobra.Command {
+	var opts importOptions
+
+	cmd := &cobra.Command{
+		Use:   "import [OPTIONS] VOLUME",
+		Short: "Import the data of a volume from a tar archive or STDIN",
+		Long:  importDescription,
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.name = args[0]
+			return runImport(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.input, "input", "i", "", "Read from tar archive file, instead of STDIN")
+
+	return cmd
+}
+
+func runImport(dockerCli *client.DockerCli, opts importOptions) error {
+	var input io.Reader = dockerCli.In()
+	if opts.input != "" {
+		file, err := os.Open(opts.input)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+
+	return dockerCli.Client().VolumeImport(context.Background(), opts.name, input)
+}
+
+var importDescription = `
+Extracts a tar archive into an existing volume. Existing files in the volume
+with the same name as a file in the archive are overwritten. The volume must
+not be in use by a container. For example, to restore the data of a volume
+exported with **docker volume export**:
+
+    $ docker volume create --name hello
+    hello
+    $ docker volume import hello < hello.tar
+
+`
@@ -6,11 +6,13 @@ import (
 	"github.com/docker/docker/api/client"
 	"github.com/docker/docker/api/client/inspect"
 	"github.com/docker/docker/cli"
+	"github.com/docker/engine-api/types"
 	"github.com/spf13/cobra"
 )
 
 type inspectOptions struct {
 	format string
+	size   bool
 	names  []string
 }
 
@@ -29,6 +31,7 @@ func newInspectCommand(dockerCli *client.DockerCli) *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&opts.format, "format", "f", "", "Format the output using the given go template")
+	cmd.Flags().BoolVarP(&opts.size, "size", "s", false, "Display the disk space used by the data of local volumes")
 
 	return cmd
 }
@@ -39,7 +42,7 @@ func runInspect(dockerCli *client.DockerCli, opts inspectOptions) error {
 	ctx := context.Background()
 
 	getVolFunc := func(name string) (interface{}, []byte, error) {
-		i, err := client.VolumeInspect(ctx, name)
+		i, _, err := client.VolumeInspectWithOptions(ctx, name, types.VolumeInspectOptions{Size: opts.size})
 		return i, nil, err
 	}
 
@@ -11,6 +11,7 @@ import (
 	"github.com/docker/docker/cli"
 	"github.com/docker/engine-api/types"
 	"github.com/docker/engine-api/types/filters"
+	"github.com/docker/go-units"
 	"github.com/spf13/cobra"
 )
 
@@ -24,6 +25,7 @@ func (r byVolumeName) Less(i, j int) bool {
 
 type listOptions struct {
 	quiet  bool
+	size   bool
 	filter []string
 }
 
@@ -43,6 +45,7 @@ func newListCommand(dockerCli *client.DockerCli) *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Only display volume names")
+	flags.BoolVarP(&opts.size, "size", "s", false, "Display the disk space used by the data of local volumes")
 	flags.StringSliceVarP(&opts.filter, "filter", "f", []string{}, "Provide filter values (i.e. 'dangling=true')")
 
 	return cmd
@@ -60,7 +63,12 @@ func runList(dockerCli *client.DockerCli, opts listOptions) error {
 		}
 	}
 
-	volumes, err := client.VolumeList(context.Background(), volFilterArgs)
+	options := types.VolumeListOptions{
+		Size:   opts.size && !opts.quiet,
+		Filter: volFilterArgs,
+	}
+
+	volumes, err := client.VolumeListWithOptions(context.Background(), options)
 	if err != nil {
 		return err
 	}
@@ -71,6 +79,9 @@ func runList(dockerCli *client.DockerCli, opts listOptions) error {
 			fmt.Fprintln(dockerCli.Err(), warn)
 		}
 		fmt.Fprintf(w, "DRIVER \tVOLUME NAME")
+		if options.Size {
+			fmt.Fprintf(w, "\tSIZE")
+		}
 		fmt.Fprintf(w, "\n")
 	}
 
@@ -80,7 +91,15 @@ func runList(dockerCli *client.DockerCli, opts listOptions) error {
 			fmt.Fprintln(w, vol.Name)
 			continue
 		}
-		fmt.Fprintf(w, "%s\t%s\n", vol.Driver, vol.Name)
+		fmt.Fprintf(w, "%s\t%s", vol.Driver, vol.Name)
+		if options.Size {
+			size := "N/A"
+			if vol.UsageData != nil && vol.UsageData.Size >= 0 {
+				size = units.HumanSize(float64(vol.UsageData.Size))
+			}
+			fmt.Fprintf(w, "\t%s", size)
+		}
+		fmt.Fprintf(w, "\n")
 	}
 	w.Flush()
 	return nil
@@ -93,7 +112,12 @@ Lists all the volumes Docker knows about. You can filter using the **-f** or
 more than one filter,  pass multiple flags (for example,
 **--filter "foo=bar" --filter "bif=baz"**)
 
-There is a single supported filter **dangling=value** which takes a boolean of
-**true** or **false**.
+The currently supported filters are:
+
+* dangling (boolean - true or false, 0 or 1)
+* driver (a volume driver's name)
+* label (label=<key> or label=<key>=<value>)
+* name (a volume's name)
+* in-use-by (a container's name or ID)
 
 `
@@ -7,30 +7,45 @@ import (
 
 	"github.com/docker/docker/api/client"
 	"github.com/docker/docker/cli"
+	"github.com/docker/engine-api/types"
 	"github.com/spf13/cobra"
 )
 
+type removeOptions struct {
+	force bool
+
+	volumes []string
+}
+
 func newRemoveCommand(dockerCli *client.DockerCli) *cobra.Command {
-	return &cobra.Command{
-		Use:     "rm VOLUME [VOLUME...]",
+	var opts removeOptions
+
+	cmd := &cobra.Command{
+		Use:     "rm [OPTIONS] VOLUME [VOLUME...]",
 		Aliases: []string{"remove"},
 		Short:   "Remove one or more volumes",
 		Long:    removeDescription,
 		Example: removeExample,
 		Args:    cli.RequiresMinArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runRemove(dockerCli, args)
+			opts.volumes = args
+			return runRemove(dockerCli, &opts)
 		},
 	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&opts.force, "force", "f", false, "Remove volumes whose driver is unavailable from the daemon only, leaving their data in the driver")
+
+	return cmd
 }
 
-func runRemove(dockerCli *client.DockerCli, volumes []string) error {
+func runRemove(dockerCli *client.DockerCli, opts *removeOptions) error {
 	client := dockerCli.Client()
 	ctx := context.Background()
 	status := 0
 
-	for _, name := range volumes {
-		if err := client.VolumeRemove(ctx, name); err != nil {
+	for _, name := range opts.volumes {
+		if err := client.VolumeRemoveWithOptions(ctx, name, types.VolumeRemoveOptions{Force: opts.force}); err != nil {
 			fmt.Fprintf(dockerCli.Err(), "%s\n", err)
 			status = 1
 			continue
@@ -46,6 +61,8 @@ func runRemove(dockerCli *client.DockerCli, volumes []string) error {
 
 var removeDescription = `
 Remove one or more volumes. You cannot remove a volume that is in use by a container.
+A volume whose driver is unavailable is only removed with --force, from the daemon
+alone: its data is left in the driver.
 `
 
 var removeExample = `
@@ -0,0 +1,67 @@
+package volume
+
+import (
+	"fmt"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/client"
+	"github.com/docker/docker/cli"
+	"github.com/docker/engine-api/types"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+type updateOptions struct {
+	name string
+	size string
+}
+
+func newUpdateCommand(dockerCli *client.DockerCli) *cobra.Command {
+	var opts updateOptions
+
+	cmd := &cobra.Command{
+		Use:   "update [OPTIONS] VOLUME",
+		Short: "Update a volume",
+		Long:  updateDescription,
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.name = args[0]
+			return runUpdate(dockerCli, opts)
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&opts.size, "size", "", "Size of the volume (e.g. 10g)")
+
+	return cmd
+}
+
+func runUpdate(dockerCli *client.DockerCli, opts updateOptions) error {
+	if opts.size == "" {
+		return fmt.Errorf("--size is required")
+	}
+	size, err := units.RAMInBytes(opts.size)
+	if err != nil {
+		return fmt.Errorf("invalid size: %s", opts.size)
+	}
+
+	req := types.VolumeUpdateRequest{Size: size}
+	if err := dockerCli.Client().VolumeUpdate(context.Background(), opts.name, req); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(dockerCli.Out(), "%s\n", opts.name)
+	return nil
+}
+
+var updateDescription = `
+Changes the size of a volume. The driver of the volume must support resizing
+volumes. The built-in **local** driver can resize volumes created with the
+**size** option on filesystems with project quotas:
+
+    $ docker volume create -o size=1g --name data
+    data
+    $ docker volume update --size 2g data
+    data
+
+`
@@ -54,6 +54,7 @@ func (s *imageRouter) postCommit(ctx context.Context, w http.ResponseWriter, r *
 			Comment:      r.Form.Get("comment"),
 			Config:       c,
 			MergeConfigs: true,
+			Squash:       httputils.BoolValue(r, "squash"),
 		},
 		Changes: r.Form["changes"],
 	}
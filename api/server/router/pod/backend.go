@@ -0,0 +1,17 @@
+package pod
+
+import (
+	// TODO return types need to be refactored into pkg
+	"github.com/docker/engine-api/types"
+)
+
+// Backend is the methods that need to be implemented to provide
+// pod specific functionality
+type Backend interface {
+	Pods() ([]*types.Pod, error)
+	PodInspect(name string) (*types.Pod, error)
+	PodCreate(req types.PodCreateRequest) (*types.PodCreateResponse, error)
+	PodStart(name string) error
+	PodStop(name string, seconds int) error
+	PodRm(name string, force bool) error
+}
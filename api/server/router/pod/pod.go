@@ -0,0 +1,37 @@
+package pod
+
+import "github.com/docker/docker/api/server/router"
+
+// podRouter is a router to talk with the pods controller
+type podRouter struct {
+	backend Backend
+	routes  []router.Route
+}
+
+// NewRouter initializes a new pod router
+func NewRouter(b Backend) router.Router {
+	r := &podRouter{
+		backend: b,
+	}
+	r.initRoutes()
+	return r
+}
+
+// Routes returns the available routes to the pods controller
+func (r *podRouter) Routes() []router.Route {
+	return r.routes
+}
+
+func (r *podRouter) initRoutes() {
+	r.routes = []router.Route{
+		// GET
+		router.NewGetRoute("/pods/json", r.getPodsList),
+		router.NewGetRoute("/pods/{name:.*}/json", r.getPodByName),
+		// POST
+		router.NewPostRoute("/pods/create", r.postPodsCreate),
+		router.NewPostRoute("/pods/{name:.*}/start", r.postPodStart),
+		router.NewPostRoute("/pods/{name:.*}/stop", r.postPodStop),
+		// DELETE
+		router.NewDeleteRoute("/pods/{name:.*}", r.deletePod),
+	}
+}
@@ -17,4 +17,6 @@ type Backend interface {
 	SubscribeToEvents(since, until time.Time, ef filters.Args) ([]events.Message, chan interface{})
 	UnsubscribeFromEvents(chan interface{})
 	AuthenticateToRegistry(ctx context.Context, authConfig *types.AuthConfig) (string, string, error)
+	NetworkUsage() (*types.NetworkUsage, error)
+	CheckPorts(ports []string) ([]types.PortCheckResult, error)
 }
@@ -26,6 +26,8 @@ func NewRouter(b Backend, c *cluster.Cluster) router.Router {
 		router.Cancellable(router.NewGetRoute("/events", r.getEvents)),
 		router.NewGetRoute("/info", r.getInfo),
 		router.NewGetRoute("/version", r.getVersion),
+		router.NewGetRoute("/system/network-usage", r.getNetworkUsage),
+		router.NewGetRoute("/system/network-usage/check", r.getNetworkPortCheck),
 		router.NewPostRoute("/auth", r.postAuth),
 	}
 
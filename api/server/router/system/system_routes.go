@@ -142,6 +142,30 @@ func (s *systemRouter) postAuth(ctx context.Context, w http.ResponseWriter, r *h
 	})
 }
 
+func (s *systemRouter) getNetworkUsage(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	usage, err := s.backend.NetworkUsage()
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusOK, usage)
+}
+
+func (s *systemRouter) getNetworkPortCheck(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	ports := r.Form["port"]
+	if len(ports) == 0 {
+		return errors.NewBadRequestError(fmt.Errorf("at least one port to check is required"))
+	}
+	results, err := s.backend.CheckPorts(ports)
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusOK, results)
+}
+
 func eventTime(formTime string) (time.Time, error) {
 	t, tNano, err := timetypes.ParseTimestamps(formTime, -1)
 	if err != nil {
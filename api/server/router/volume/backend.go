@@ -1,6 +1,8 @@
 package volume
 
 import (
+	"io"
+
 	// TODO return types need to be refactored into pkg
 	"github.com/docker/engine-api/types"
 )
@@ -8,8 +10,12 @@ import (
 // Backend is the methods that need to be implemented to provide
 // volume specific functionality
 type Backend interface {
-	Volumes(filter string) ([]*types.Volume, []string, error)
-	VolumeInspect(name string) (*types.Volume, error)
+	Volumes(filter string, size bool) ([]*types.Volume, []string, error)
+	VolumeInspect(name string, size bool) (*types.Volume, error)
 	VolumeCreate(name, driverName string, opts, labels map[string]string) (*types.Volume, error)
-	VolumeRm(name string) error
+	VolumeRm(name string, force bool) error
+	VolumeExport(name string) (io.ReadCloser, error)
+	VolumeImport(name string, data io.Reader) error
+	VolumeClone(source, name string, opts, labels map[string]string) (*types.Volume, error)
+	VolumeUpdate(name string, size int64) error
 }
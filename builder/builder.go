@@ -115,8 +115,9 @@ type Backend interface {
 	PullOnBuild(ctx context.Context, name string, authConfigs map[string]types.AuthConfig, output io.Writer) (Image, error)
 	// ContainerAttachRaw attaches to container.
 	ContainerAttachRaw(cID string, stdin io.ReadCloser, stdout, stderr io.Writer, stream bool) error
-	// ContainerCreate creates a new Docker container and returns potential warnings
-	ContainerCreate(config types.ContainerCreateConfig) (types.ContainerCreateResponse, error)
+	// ContainerCreateForBuild creates a new Docker container running a step
+	// of the build and returns potential warnings
+	ContainerCreateForBuild(config types.ContainerCreateConfig) (types.ContainerCreateResponse, error)
 	// ContainerRm removes a container specified by `id`.
 	ContainerRm(name string, config *types.ContainerRmConfig) error
 	// Commit creates a new Docker image from an existing Docker container.
@@ -138,6 +139,11 @@ type Backend interface {
 	//ContainerCopy(name string, res string) (io.ReadCloser, error)
 	// TODO: use copyBackend api
 	CopyOnBuild(containerID string, destPath string, src FileInfo, decompress bool) error
+
+	// SquashImage squashes the layers of the image referenced by `id` that
+	// were added on top of the image referenced by `parent` into a single
+	// layer, and returns the ID of the new image.
+	SquashImage(id, parent string) (string, error)
 }
 
 // Image represents a Docker image used by the builder.
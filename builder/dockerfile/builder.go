@@ -65,6 +65,7 @@ type Builder struct {
 	flags            *BFlags
 	tmpContainers    map[string]struct{}
 	image            string // imageID
+	baseImage        string // imageID of the last FROM, used as the squash parent
 	noBaseImage      bool
 	maintainer       string
 	cmdSet           bool
@@ -272,6 +273,17 @@ func (b *Builder) build(stdout io.Writer, stderr io.Writer, out io.Writer) (stri
 		return "", fmt.Errorf("No image was generated. Is your Dockerfile empty?")
 	}
 
+	if b.options.Squash {
+		fmt.Fprintf(b.Stdout, "Squashing layers added on top of the base image\n")
+		squashedID, err := b.docker.SquashImage(b.image, b.baseImage)
+		if err != nil {
+			return "", fmt.Errorf("error squashing image: %v", err)
+		}
+		b.image = squashedID
+		shortImgID = stringid.TruncateID(b.image)
+		fmt.Fprintf(b.Stdout, " ---> %s\n", shortImgID)
+	}
+
 	imageID := image.ID(b.image)
 	for _, rt := range repoAndTags {
 		if err := b.docker.TagImageWithReference(imageID, rt); err != nil {
@@ -9,6 +9,7 @@ package dockerfile
 
 import (
 	"fmt"
+	"net/http"
 	"regexp"
 	"runtime"
 	"sort"
@@ -203,6 +204,12 @@ func from(b *Builder, args []string, attributes map[string]bool, original string
 		if !b.options.PullParent {
 			image, err = b.docker.GetImageOnBuild(name)
 			// TODO: shouldn't we error out if error is different from "not found" ?
+			// an image rejected by the signature policy is not pulled again
+			if e, ok := err.(interface {
+				HTTPErrorStatusCode() int
+			}); ok && e.HTTPErrorStatusCode() == http.StatusForbidden {
+				return err
+			}
 		}
 		if image == nil {
 			image, err = b.docker.PullOnBuild(b.clientCtx, name, b.options.AuthConfigs, b.Output)
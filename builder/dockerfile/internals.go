@@ -181,7 +181,7 @@ func (b *Builder) runContextCommand(args []string, allowRemote bool, allowLocalD
 		return nil
 	}
 
-	container, err := b.docker.ContainerCreate(types.ContainerCreateConfig{Config: b.runConfig})
+	container, err := b.docker.ContainerCreateForBuild(types.ContainerCreateConfig{Config: b.runConfig})
 	if err != nil {
 		return err
 	}
@@ -386,8 +386,10 @@ func containsWildcards(name string) bool {
 }
 
 func (b *Builder) processImageFrom(img builder.Image) error {
+	b.baseImage = ""
 	if img != nil {
 		b.image = img.ImageID()
+		b.baseImage = b.image
 
 		if img.RunConfig() != nil {
 			b.runConfig = img.RunConfig()
@@ -505,7 +507,7 @@ func (b *Builder) create() (string, error) {
 	config := *b.runConfig
 
 	// Create the container
-	c, err := b.docker.ContainerCreate(types.ContainerCreateConfig{
+	c, err := b.docker.ContainerCreateForBuild(types.ContainerCreateConfig{
 		Config:     b.runConfig,
 		HostConfig: hostConfig,
 	})
@@ -7,6 +7,7 @@ import (
 	"github.com/docker/docker/api/client/network"
 	"github.com/docker/docker/api/client/node"
 	"github.com/docker/docker/api/client/plugin"
+	"github.com/docker/docker/api/client/pod"
 	"github.com/docker/docker/api/client/registry"
 	"github.com/docker/docker/api/client/service"
 	"github.com/docker/docker/api/client/stack"
@@ -80,6 +81,7 @@ func NewCobraAdaptor(clientFlags *cliflags.ClientFlags) CobraAdaptor {
 		image.NewImportCommand(dockerCli),
 		image.NewTagCommand(dockerCli),
 		network.NewNetworkCommand(dockerCli),
+		pod.NewPodCommand(dockerCli),
 		system.NewEventsCommand(dockerCli),
 		registry.NewLoginCommand(dockerCli),
 		registry.NewLogoutCommand(dockerCli),
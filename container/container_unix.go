@@ -297,6 +297,12 @@ func (container *Container) UpdateContainer(hostConfig *containertypes.HostConfi
 	if resources.KernelMemory != 0 {
 		cResources.KernelMemory = resources.KernelMemory
 	}
+	if resources.NetworkIngressRate != 0 {
+		cResources.NetworkIngressRate = resources.NetworkIngressRate
+	}
+	if resources.NetworkEgressRate != 0 {
+		cResources.NetworkEgressRate = resources.NetworkEgressRate
+	}
 
 	// update HostConfig of container
 	if hostConfig.RestartPolicy.Name != "" {
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/api/types/backend"
 	"github.com/docker/docker/builder/dockerfile"
 	"github.com/docker/docker/container"
@@ -224,6 +225,25 @@ func (daemon *Daemon) Commit(name string, c *backend.ContainerCommitConfig) (str
 		}
 	}
 
+	if c.Squash {
+		var base image.ID
+		if container.ImageID != "" {
+			base = daemon.squashBase(container.ImageID)
+		}
+		squashedID, err := daemon.SquashImage(id.String(), base.String())
+		if err != nil {
+			if _, err := daemon.imageStore.Delete(id); err != nil {
+				logrus.Errorf("Failed to remove unsquashed image %s: %v", id, err)
+			}
+			return "", err
+		}
+		// The unsquashed image is only an intermediate step of the commit
+		if _, err := daemon.imageStore.Delete(id); err != nil {
+			logrus.Errorf("Failed to remove unsquashed image %s: %v", id, err)
+		}
+		id = image.ID(squashedID)
+	}
+
 	if c.Repo != "" {
 		newTag, err := reference.WithName(c.Repo) // todo: should move this to API layer
 		if err != nil {
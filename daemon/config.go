@@ -6,11 +6,14 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"os/exec"
 	"strings"
 	"sync"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/distribution"
 	"github.com/docker/docker/opts"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/discovery"
 	flag "github.com/docker/docker/pkg/mflag"
 	"github.com/docker/docker/registry"
@@ -26,6 +29,9 @@ const (
 	// maximum number of uploads that
 	// may take place at a time for each push.
 	defaultMaxConcurrentUploads = 5
+	// defaultPushCompression is the default compression algorithm
+	// applied to pushed layers.
+	defaultPushCompression = "gzip"
 	// stockRuntimeName is the reserved name/alias used to represent the
 	// OCI runtime being shipped with the docker daemon package.
 	stockRuntimeName = "runc"
@@ -43,6 +49,7 @@ const (
 var flatOptions = map[string]bool{
 	"cluster-store-opts": true,
 	"log-opts":           true,
+	"registry-mirrors":   true,
 	"runtimes":           true,
 }
 
@@ -118,6 +125,20 @@ type CommonConfig struct {
 	// may take place at a time for each push.
 	MaxConcurrentUploads *int `json:"max-concurrent-uploads,omitempty"`
 
+	// PushCompression is the compression algorithm applied to the
+	// layers pushed to a registry, either gzip or zstd.
+	PushCompression string `json:"push-compression,omitempty"`
+
+	// PushCompressionLevel is the compression level applied to the
+	// layers pushed to a registry. 0 selects the default level of
+	// the compression algorithm.
+	PushCompressionLevel int `json:"push-compression-level,omitempty"`
+
+	// SignaturePolicy is the path to the signature policy file, which
+	// defines the signatures images need to have to be pulled, and to
+	// create containers from.
+	SignaturePolicy string `json:"signature-policy,omitempty"`
+
 	Debug     bool     `json:"debug,omitempty"`
 	Hosts     []string `json:"hosts,omitempty"`
 	LogLevel  string   `json:"log-level,omitempty"`
@@ -173,6 +194,9 @@ func (config *Config) InstallCommonFlags(cmd *flag.FlagSet, usageFn func(string)
 	cmd.StringVar(&config.CorsHeaders, []string{"-api-cors-header"}, "", usageFn("Set CORS headers in the remote API"))
 	cmd.IntVar(&maxConcurrentDownloads, []string{"-max-concurrent-downloads"}, defaultMaxConcurrentDownloads, usageFn("Set the max concurrent downloads for each pull"))
 	cmd.IntVar(&maxConcurrentUploads, []string{"-max-concurrent-uploads"}, defaultMaxConcurrentUploads, usageFn("Set the max concurrent uploads for each push"))
+	cmd.StringVar(&config.PushCompression, []string{"-push-compression"}, defaultPushCompression, usageFn("Set the compression algorithm for pushed layers (gzip, zstd)"))
+	cmd.IntVar(&config.PushCompressionLevel, []string{"-push-compression-level"}, 0, usageFn("Set the compression level for pushed layers, 0 for the default level"))
+	cmd.StringVar(&config.SignaturePolicy, []string{"-signature-policy"}, "", usageFn("Signature policy for pulled images and containers"))
 
 	cmd.StringVar(&config.SwarmDefaultAdvertiseAddr, []string{"-swarm-default-advertise-addr"}, "", usageFn("Set default address or interface for swarm advertised address"))
 
@@ -180,6 +204,28 @@ func (config *Config) InstallCommonFlags(cmd *flag.FlagSet, usageFn func(string)
 	config.MaxConcurrentUploads = &maxConcurrentUploads
 }
 
+// pushCompression returns the compression algorithm and level applied to
+// pushed layers.
+func (config *Config) pushCompression() (archive.Compression, int, error) {
+	name := config.PushCompression
+	if name == "" {
+		name = defaultPushCompression
+	}
+	compression, err := archive.ParseCompression(name)
+	if err != nil {
+		return archive.Uncompressed, 0, err
+	}
+	if err := distribution.ValidateLayerCompression(compression, config.PushCompressionLevel); err != nil {
+		return archive.Uncompressed, 0, err
+	}
+	if compression == archive.Zstd {
+		if _, err := exec.LookPath("zstd"); err != nil {
+			return archive.Uncompressed, 0, fmt.Errorf("zstd push compression requires the zstd binary: %v", err)
+		}
+	}
+	return compression, config.PushCompressionLevel, nil
+}
+
 // IsValueSet returns true if a configuration value
 // was explicitly set in the configuration file.
 func (config *Config) IsValueSet(name string) bool {
@@ -425,6 +471,13 @@ func ValidateConfiguration(config *Config) error {
 		}
 	}
 
+	// validate Mirrors
+	for _, mirror := range config.Mirrors {
+		if _, err := registry.ValidateMirror(mirror); err != nil {
+			return err
+		}
+	}
+
 	// validate MaxConcurrentDownloads
 	if config.IsValueSet("max-concurrent-downloads") && config.MaxConcurrentDownloads != nil && *config.MaxConcurrentDownloads < 0 {
 		return fmt.Errorf("invalid max concurrent downloads: %d", *config.MaxConcurrentDownloads)
@@ -435,6 +488,11 @@ func ValidateConfiguration(config *Config) error {
 		return fmt.Errorf("invalid max concurrent uploads: %d", *config.MaxConcurrentUploads)
 	}
 
+	// validate PushCompression and PushCompressionLevel
+	if _, _, err := config.pushCompression(); err != nil {
+		return err
+	}
+
 	// validate that "default" runtime is not reset
 	if runtimes := config.GetAllRuntimes(); len(runtimes) > 0 {
 		if _, ok := runtimes[stockRuntimeName]; ok {
@@ -612,6 +612,12 @@ func (daemon *Daemon) connectToNetwork(container *container.Container, idOrName
 		return fmt.Errorf("Updating join info failed: %v", err)
 	}
 
+	if container.HostConfig.NetworkIngressRate > 0 || container.HostConfig.NetworkEgressRate > 0 {
+		if err := daemon.setNetworkRate(container); err != nil {
+			return fmt.Errorf("Applying network rate limits failed: %v", err)
+		}
+	}
+
 	container.NetworkSettings.Ports = getPortMapInfo(sb)
 
 	daemon.LogNetworkEventWithAttributes(n, "connect", map[string]string{"container": container.ID})
@@ -758,6 +764,10 @@ func (daemon *Daemon) releaseNetwork(container *container.Container) {
 	if err := sb.Delete(); err != nil {
 		logrus.Errorf("Error deleting sandbox id %s for container %s: %v", sid, container.ID, err)
 	}
+	if container.HostConfig.NetworkEgressRate > 0 {
+		// the veth peers of the container were deleted with its sandbox
+		removeStaleIfbs()
+	}
 
 	for _, nw := range networks {
 		attributes := map[string]string{
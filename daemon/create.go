@@ -11,6 +11,7 @@ import (
 	"github.com/docker/docker/layer"
 	"github.com/docker/docker/pkg/idtools"
 	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/docker/reference"
 	"github.com/docker/docker/runconfig"
 	volumestore "github.com/docker/docker/volume/store"
 	"github.com/docker/engine-api/types"
@@ -21,19 +22,33 @@ import (
 
 // CreateManagedContainer creates a container that is managed by a Service
 func (daemon *Daemon) CreateManagedContainer(params types.ContainerCreateConfig) (types.ContainerCreateResponse, error) {
-	return daemon.containerCreate(params, true)
+	return daemon.containerCreate(params, true, false)
 }
 
 // ContainerCreate creates a regular container
 func (daemon *Daemon) ContainerCreate(params types.ContainerCreateConfig) (types.ContainerCreateResponse, error) {
-	return daemon.containerCreate(params, false)
+	return daemon.containerCreate(params, false, false)
 }
 
-func (daemon *Daemon) containerCreate(params types.ContainerCreateConfig, managed bool) (types.ContainerCreateResponse, error) {
+// ContainerCreateForBuild creates a container running a step of a build. The
+// signature policy is not enforced, as the images of the intermediate steps
+// of a build are not signed, and the base image of the build is verified
+// when it is looked up, see GetImageOnBuild.
+func (daemon *Daemon) ContainerCreateForBuild(params types.ContainerCreateConfig) (types.ContainerCreateResponse, error) {
+	return daemon.containerCreate(params, false, true)
+}
+
+func (daemon *Daemon) containerCreate(params types.ContainerCreateConfig, managed, build bool) (types.ContainerCreateResponse, error) {
 	if params.Config == nil {
 		return types.ContainerCreateResponse{}, fmt.Errorf("Config cannot be empty in order to create a container")
 	}
 
+	if params.HostConfig != nil && params.HostConfig.Pod != "" {
+		if err := daemon.joinPod(params.HostConfig); err != nil {
+			return types.ContainerCreateResponse{}, err
+		}
+	}
+
 	warnings, err := daemon.verifyContainerSettings(params.HostConfig, params.Config, false)
 	if err != nil {
 		return types.ContainerCreateResponse{Warnings: warnings}, err
@@ -52,7 +67,7 @@ func (daemon *Daemon) containerCreate(params types.ContainerCreateConfig, manage
 		return types.ContainerCreateResponse{Warnings: warnings}, err
 	}
 
-	container, err := daemon.create(params, managed)
+	container, err := daemon.create(params, managed, build)
 	if err != nil {
 		return types.ContainerCreateResponse{Warnings: warnings}, daemon.imageNotExistToErrcode(err)
 	}
@@ -61,7 +76,7 @@ func (daemon *Daemon) containerCreate(params types.ContainerCreateConfig, manage
 }
 
 // Create creates a new container from the given configuration with a given name.
-func (daemon *Daemon) create(params types.ContainerCreateConfig, managed bool) (retC *container.Container, retErr error) {
+func (daemon *Daemon) create(params types.ContainerCreateConfig, managed, build bool) (retC *container.Container, retErr error) {
 	var (
 		container *container.Container
 		img       *image.Image
@@ -75,6 +90,12 @@ func (daemon *Daemon) create(params types.ContainerCreateConfig, managed bool) (
 			return nil, err
 		}
 		imgID = img.ID()
+
+		if !build {
+			if err := daemon.verifyImageSignatures(params.Config.Image, imgID); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	if err := daemon.mergeAndVerifyConfig(params.Config, img); err != nil {
@@ -225,6 +246,9 @@ func (daemon *Daemon) VolumeCreate(name, driverName string, opts, labels map[str
 		if volumestore.IsNameConflict(err) {
 			return nil, fmt.Errorf("A volume named %s already exists. Choose a different volume name.", name)
 		}
+		if volumestore.IsValidationError(err) {
+			return nil, errors.NewBadRequestError(err)
+		}
 		return nil, err
 	}
 
@@ -258,3 +282,50 @@ func (daemon *Daemon) verifyNetworkingConfig(nwConfig *networktypes.NetworkingCo
 	err := fmt.Errorf("Container cannot be connected to network endpoints: %s", strings.Join(l, ", "))
 	return errors.NewBadRequestError(err)
 }
+
+// verifyImageSignatures checks that the image referenced by refOrID has the
+// signatures required by the signature policy of the daemon. If the image is
+// referenced by name, the policy of the registry in that name applies. An
+// image without signatures, such as a locally built or committed image, is
+// trusted if the image it is based on is.
+func (daemon *Daemon) verifyImageSignatures(refOrID string, imgID image.ID) error {
+	if daemon.signaturePolicy == nil {
+		return nil
+	}
+
+	var named reference.Named
+	if _, ref, err := reference.ParseIDOrReference(refOrID); err == nil && ref != nil {
+		for _, r := range daemon.referenceStore.References(imgID) {
+			if r.Name() == ref.Name() {
+				named = ref
+				break
+			}
+		}
+	}
+	return daemon.verifyImageChain(named, imgID, false)
+}
+
+// verifyImageChain checks the signatures of the image, or of the first image
+// with signatures in its parent chain. base is set for the parents of the
+// image referenced by named, whose signatures are for other repositories.
+func (daemon *Daemon) verifyImageChain(named reference.Named, imgID image.ID, base bool) error {
+	records, err := daemon.signatureStore.Get(imgID)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		if parent, err := daemon.imageStore.GetParent(imgID); err == nil && parent != "" {
+			return daemon.verifyImageChain(named, parent, true)
+		}
+	}
+
+	if base && named != nil {
+		err = daemon.signaturePolicy.VerifyBaseImage(named, records)
+	} else {
+		err = daemon.signaturePolicy.VerifyImage(named, records)
+	}
+	if err != nil {
+		return errors.NewRequestForbiddenError(err)
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/pkg/stringid"
+	imagevolume "github.com/docker/docker/volume/image"
 	containertypes "github.com/docker/engine-api/types/container"
 	"github.com/opencontainers/runc/libcontainer/label"
 )
@@ -63,7 +64,8 @@ func (daemon *Daemon) createContainerPlatformSpecificSettings(container *contain
 // this is only called when the container is created.
 func (daemon *Daemon) populateVolumes(c *container.Container) error {
 	for _, mnt := range c.MountPoints {
-		if !mnt.CopyData || mnt.Volume == nil {
+		// image volumes are read-only and already hold the image data
+		if !mnt.CopyData || mnt.Volume == nil || mnt.Volume.DriverName() == imagevolume.DriverName {
 			continue
 		}
 
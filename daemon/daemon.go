@@ -32,6 +32,7 @@ import (
 	// register graph drivers
 	_ "github.com/docker/docker/daemon/graphdriver/register"
 	dmetadata "github.com/docker/docker/distribution/metadata"
+	"github.com/docker/docker/distribution/signature"
 	"github.com/docker/docker/distribution/xfer"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/layer"
@@ -47,11 +48,13 @@ import (
 	"github.com/docker/docker/pkg/sysinfo"
 	"github.com/docker/docker/pkg/system"
 	"github.com/docker/docker/pkg/truncindex"
+	"github.com/docker/docker/pod"
 	"github.com/docker/docker/reference"
 	"github.com/docker/docker/registry"
 	"github.com/docker/docker/runconfig"
 	"github.com/docker/docker/utils"
 	volumedrivers "github.com/docker/docker/volume/drivers"
+	imagevolume "github.com/docker/docker/volume/image"
 	"github.com/docker/docker/volume/local"
 	"github.com/docker/docker/volume/store"
 	"github.com/docker/libnetwork"
@@ -77,6 +80,8 @@ type Daemon struct {
 	downloadManager           *xfer.LayerDownloadManager
 	uploadManager             *xfer.LayerUploadManager
 	distributionMetadataStore dmetadata.Store
+	signaturePolicy           *signature.Policy
+	signatureStore            *signature.Store
 	trustKey                  libtrust.PrivateKey
 	idIndex                   *truncindex.TruncIndex
 	configStore               *Config
@@ -86,6 +91,7 @@ type Daemon struct {
 	EventsService             *events.Events
 	netController             libnetwork.NetworkController
 	volumes                   *store.VolumeStore
+	pods                      *pod.Store
 	discoveryWatcher          discoveryReloader
 	root                      string
 	seccompEnabled            bool
@@ -173,9 +179,13 @@ func (daemon *Daemon) restore() error {
 		wg.Add(1)
 		go func(c *container.Container) {
 			defer wg.Done()
-			rm := c.RestartManager(false)
+			var restoreOptions []libcontainerd.CreateOption
+			// containers of a pod are restarted by the daemon, see podContainerExited
+			if c.HostConfig.Pod == "" {
+				restoreOptions = append(restoreOptions, libcontainerd.WithRestartManager(c.RestartManager(false)))
+			}
 			if c.IsRunning() || c.IsPaused() {
-				if err := daemon.containerd.Restore(c.ID, c.InitializeStdio, libcontainerd.WithRestartManager(rm)); err != nil {
+				if err := daemon.containerd.Restore(c.ID, c.InitializeStdio, restoreOptions...); err != nil {
 					logrus.Errorf("Failed to restore %s with containerd: %s", c.ID, err)
 					return
 				}
@@ -224,6 +234,9 @@ func (daemon *Daemon) restore() error {
 	if err != nil {
 		return fmt.Errorf("Error initializing network controller: %v", err)
 	}
+	// the veth peers of the containers which did not survive the restart
+	// were deleted with their sandboxes
+	removeStaleIfbs()
 
 	// migrate any legacy links from sqlite
 	linkdbFile := filepath.Join(daemon.root, "linkgraph.db")
@@ -524,6 +537,11 @@ func NewDaemon(config *Config, registryService registry.Service, containerdRemot
 		return nil, err
 	}
 
+	d.pods, err = pod.NewStore(filepath.Join(config.Root, "pods"))
+	if err != nil {
+		return nil, err
+	}
+
 	trustKey, err := api.LoadOrCreateTrustKey(config.TrustKeyPath)
 	if err != nil {
 		return nil, err
@@ -540,6 +558,14 @@ func NewDaemon(config *Config, registryService registry.Service, containerdRemot
 		return nil, err
 	}
 
+	var signaturePolicy *signature.Policy
+	if config.SignaturePolicy != "" {
+		signaturePolicy, err = signature.LoadPolicy(config.SignaturePolicy)
+		if err != nil {
+			return nil, fmt.Errorf("Couldn't load signature policy: %v", err)
+		}
+	}
+
 	eventsService := events.New()
 
 	referenceStore, err := reference.NewReferenceStore(filepath.Join(imageRoot, "repositories.json"))
@@ -576,6 +602,8 @@ func NewDaemon(config *Config, registryService registry.Service, containerdRemot
 	d.execCommands = exec.NewStore()
 	d.referenceStore = referenceStore
 	d.distributionMetadataStore = distributionMetadataStore
+	d.signaturePolicy = signaturePolicy
+	d.signatureStore = signature.NewStore(distributionMetadataStore)
 	d.trustKey = trustKey
 	d.idIndex = truncindex.NewTruncIndex([]string{})
 	d.statsCollector = d.newStatsCollector(1 * time.Second)
@@ -586,6 +614,7 @@ func NewDaemon(config *Config, registryService registry.Service, containerdRemot
 	d.RegistryService = registryService
 	d.EventsService = eventsService
 	d.volumes = volStore
+	d.volumes.Reconcile(d.LogVolumeEvent)
 	d.root = config.Root
 	d.uidMaps = uidMaps
 	d.gidMaps = gidMaps
@@ -853,6 +882,17 @@ func (daemon *Daemon) configureVolumes(rootUID, rootGID int) (*store.VolumeStore
 	if !volumedrivers.Register(volumesDriver, volumesDriver.Name()) {
 		return nil, fmt.Errorf("local volume driver could not be registered")
 	}
+
+	// image volumes mount image layers, which is not supported on Windows
+	if runtime.GOOS != "windows" {
+		imageDriver, err := imagevolume.New(daemon.configStore.Root, daemon.layerStore, daemon.GetImage)
+		if err != nil {
+			return nil, err
+		}
+		if !volumedrivers.Register(imageDriver, imageDriver.Name()) {
+			return nil, fmt.Errorf("image volume driver could not be registered")
+		}
+	}
 	return store.New(daemon.configStore.Root)
 }
 
@@ -888,6 +928,7 @@ func (daemon *Daemon) initDiscovery(config *Config) error {
 // - Daemon debug log level.
 // - Daemon max concurrent downloads
 // - Daemon max concurrent uploads
+// - Daemon push compression algorithm and level
 // - Cluster discovery (reconfigure and restart).
 // - Daemon live restore
 func (daemon *Daemon) Reload(config *Config) error {
@@ -952,6 +993,13 @@ func (daemon *Daemon) Reload(config *Config) error {
 		daemon.uploadManager.SetConcurrency(*daemon.configStore.MaxConcurrentUploads)
 	}
 
+	if config.IsValueSet("push-compression") {
+		daemon.configStore.PushCompression = config.PushCompression
+	}
+	if config.IsValueSet("push-compression-level") {
+		daemon.configStore.PushCompressionLevel = config.PushCompressionLevel
+	}
+
 	// We emit daemon reload event here with updatable configurations
 	attributes["debug"] = fmt.Sprintf("%t", daemon.configStore.Debug)
 	attributes["cluster-store"] = daemon.configStore.ClusterStore
@@ -970,6 +1018,8 @@ func (daemon *Daemon) Reload(config *Config) error {
 	}
 	attributes["max-concurrent-downloads"] = fmt.Sprintf("%d", *daemon.configStore.MaxConcurrentDownloads)
 	attributes["max-concurrent-uploads"] = fmt.Sprintf("%d", *daemon.configStore.MaxConcurrentUploads)
+	attributes["push-compression"] = daemon.configStore.PushCompression
+	attributes["push-compression-level"] = fmt.Sprintf("%d", daemon.configStore.PushCompressionLevel)
 
 	return nil
 }
@@ -6,6 +6,7 @@ import (
 	"bytes"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"net"
 	"os"
 	"path/filepath"
@@ -401,6 +402,14 @@ func verifyContainerResources(resources *containertypes.Resources, sysInfo *sysi
 	if resources.IOMaximumBandwidth != 0 || resources.IOMaximumIOps != 0 {
 		return warnings, fmt.Errorf("Invalid QoS settings: %s does not support Maximum IO Bandwidth or Maximum IO IOps", runtime.GOOS)
 	}
+
+	// network rate checks
+	if resources.NetworkIngressRate < -1 || resources.NetworkIngressRate > math.MaxUint32 {
+		return warnings, fmt.Errorf("Invalid network ingress rate %d, range is from 1 to %d bytes per second, or 0 to leave it unset and -1 to remove the limit", resources.NetworkIngressRate, uint32(math.MaxUint32))
+	}
+	if resources.NetworkEgressRate < -1 || resources.NetworkEgressRate > math.MaxUint32 {
+		return warnings, fmt.Errorf("Invalid network egress rate %d, range is from 1 to %d bytes per second, or 0 to leave it unset and -1 to remove the limit", resources.NetworkEgressRate, uint32(math.MaxUint32))
+	}
 	if len(resources.BlkioWeightDevice) > 0 && !sysInfo.BlkioWeightDevice {
 		warnings = append(warnings, "Your kernel does not support Block I/O weight_device.")
 		logrus.Warn("Your kernel does not support Block I/O weight_device. Weight-device discarded.")
@@ -490,6 +499,10 @@ func verifyPlatformContainerSettings(daemon *Daemon, hostConfig *containertypes.
 		return warnings, fmt.Errorf("Invalid value %d, range for oom score adj is [-1000, 1000]", hostConfig.OomScoreAdj)
 	}
 
+	if (hostConfig.NetworkIngressRate > 0 || hostConfig.NetworkEgressRate > 0) && (hostConfig.NetworkMode.IsHost() || hostConfig.NetworkMode.IsContainer()) {
+		return warnings, fmt.Errorf("Conflicting options: network rate limits cannot be used with the host or container network modes")
+	}
+
 	// ip-forwarding does not affect container with '--net=host' (or '--net=none')
 	if sysInfo.IPv4ForwardingDisabled && !(hostConfig.NetworkMode.IsHost() || hostConfig.NetworkMode.IsNone()) {
 		warnings = append(warnings, "IPv4 forwarding is disabled. Networking will not work.")
@@ -561,7 +574,7 @@ func verifyDaemonSettings(config *Config) error {
 	if !config.bridgeConfig.EnableIPTables && !config.bridgeConfig.InterContainerCommunication {
 		return fmt.Errorf("You specified --iptables=false with --icc=false. ICC=false uses iptables to function. Please set --icc or --iptables to true")
 	}
-	if !config.bridgeConfig.EnableIPTables && config.bridgeConfig.EnableIPMasq {
+	if !config.bridgeConfig.EnableIPTables && !config.bridgeConfig.EnableIP6Tables && config.bridgeConfig.EnableIPMasq {
 		config.bridgeConfig.EnableIPMasq = false
 	}
 	if err := VerifyCgroupDriver(config); err != nil {
@@ -674,6 +687,7 @@ func driverOptions(config *Config) []nwconfig.Option {
 	bridgeConfig := options.Generic{
 		"EnableIPForwarding":  config.bridgeConfig.EnableIPForward,
 		"EnableIPTables":      config.bridgeConfig.EnableIPTables,
+		"EnableIP6Tables":     config.bridgeConfig.EnableIP6Tables,
 		"EnableUserlandProxy": config.bridgeConfig.EnableUserlandProxy}
 	bridgeOption := options.Generic{netlabel.GenericData: bridgeConfig}
 
@@ -24,6 +24,10 @@ func (daemon *Daemon) ContainerRm(name string, config *types.ContainerRmConfig)
 		return err
 	}
 
+	if p := daemon.pods.GetByInfraContainer(container.ID); p != nil {
+		return errors.NewRequestConflictError(fmt.Errorf("Unable to remove container %s, it is the infra container of pod %s. Remove the pod instead", name, p.Name))
+	}
+
 	// Container state RemovalInProgress should be used to avoid races.
 	if inProgress := container.SetRemovalInProgress(); inProgress {
 		return nil
@@ -138,8 +142,10 @@ func (daemon *Daemon) cleanupContainer(container *container.Container, forceRemo
 
 // VolumeRm removes the volume with the given name.
 // If the volume is referenced by a container it is not removed
+// A volume whose driver is unavailable is only removed with force, from the
+// daemon alone: its data is left in the driver.
 // This is called directly from the remote API
-func (daemon *Daemon) VolumeRm(name string) error {
+func (daemon *Daemon) VolumeRm(name string, force bool) error {
 	v, err := daemon.volumes.Get(name)
 	if err != nil {
 		return err
@@ -150,7 +156,15 @@ func (daemon *Daemon) VolumeRm(name string) error {
 			err := fmt.Errorf("Unable to remove volume, volume still in use: %v", err)
 			return errors.NewRequestConflictError(err)
 		}
-		return fmt.Errorf("Error while removing volume %s: %v", name, err)
+		if !volumestore.IsUnavailable(err) {
+			return fmt.Errorf("Error while removing volume %s: %v", name, err)
+		}
+		if !force {
+			err := fmt.Errorf("Unable to remove volume %s, its driver %s is unavailable: use force to remove it from the daemon only, leaving its data in the driver", name, v.DriverName())
+			return errors.NewRequestConflictError(err)
+		}
+		logrus.Warnf("Removing unavailable volume %s from the daemon, its data is left in driver %s", name, v.DriverName())
+		daemon.volumes.Purge(name)
 	}
 	daemon.LogVolumeEvent(v.Name(), "destroy", map[string]string{"driver": v.DriverName()})
 	return nil
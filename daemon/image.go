@@ -63,12 +63,17 @@ func (daemon *Daemon) GetImage(refOrID string) (*image.Image, error) {
 	return daemon.imageStore.Get(imgID)
 }
 
-// GetImageOnBuild looks up a Docker image referenced by `name`.
+// GetImageOnBuild looks up a Docker image referenced by `name`, which must
+// satisfy the signature policy of the daemon to be the base image of a
+// build.
 func (daemon *Daemon) GetImageOnBuild(name string) (builder.Image, error) {
 	img, err := daemon.GetImage(name)
 	if err != nil {
 		return nil, err
 	}
+	if err := daemon.verifyImageSignatures(name, img.ID()); err != nil {
+		return nil, err
+	}
 	return img, nil
 }
 
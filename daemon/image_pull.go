@@ -70,7 +70,7 @@ func (daemon *Daemon) PullOnBuild(ctx context.Context, name string, authConfigs
 	if err := daemon.pullImageWithReference(ctx, ref, nil, pullRegistryAuth, output); err != nil {
 		return nil, err
 	}
-	return daemon.GetImage(name)
+	return daemon.GetImageOnBuild(name)
 }
 
 func (daemon *Daemon) pullImageWithReference(ctx context.Context, ref reference.Named, metaHeaders map[string][]string, authConfig *types.AuthConfig, outStream io.Writer) error {
@@ -97,6 +97,8 @@ func (daemon *Daemon) pullImageWithReference(ctx context.Context, ref reference.
 		ImageStore:       daemon.imageStore,
 		ReferenceStore:   daemon.referenceStore,
 		DownloadManager:  daemon.downloadManager,
+		SignaturePolicy:  daemon.signaturePolicy,
+		SignatureStore:   daemon.signatureStore,
 	}
 
 	err := distribution.Pull(ctx, ref, imagePullConfig)
@@ -24,6 +24,11 @@ func (daemon *Daemon) PushImage(ctx context.Context, image, tag string, metaHead
 		}
 	}
 
+	compression, compressionLevel, err := daemon.configStore.pushCompression()
+	if err != nil {
+		return err
+	}
+
 	// Include a buffer so that slow client connections don't affect
 	// transfer performance.
 	progressChan := make(chan progress.Progress, 100)
@@ -49,6 +54,9 @@ func (daemon *Daemon) PushImage(ctx context.Context, image, tag string, metaHead
 		ReferenceStore:   daemon.referenceStore,
 		TrustKey:         daemon.trustKey,
 		UploadManager:    daemon.uploadManager,
+
+		LayerCompression:      compression,
+		LayerCompressionLevel: compressionLevel,
 	}
 
 	err = distribution.Push(ctx, ref, imagePushConfig)
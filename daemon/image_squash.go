@@ -0,0 +1,122 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+)
+
+// SquashImage creates a new image with the diff of the specified image and
+// the specified parent. This new image contains only the layers from its
+// parent plus a single new layer which contains the diff of all the layers
+// in between. The existing image(s) remain unchanged. The history entries of
+// the squashed layers are preserved, but marked as empty layers.
+func (daemon *Daemon) SquashImage(id, parent string) (string, error) {
+	img, err := daemon.imageStore.Get(image.ID(id))
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		parentImg     *image.Image
+		parentChainID layer.ChainID
+	)
+	if len(parent) != 0 {
+		parentImg, err = daemon.imageStore.Get(image.ID(parent))
+		if err != nil {
+			return "", fmt.Errorf("error getting specified parent layer: %v", err)
+		}
+		parentChainID = parentImg.RootFS.ChainID()
+	} else {
+		parentImg = &image.Image{RootFS: image.NewRootFS()}
+	}
+
+	l, err := daemon.layerStore.Get(img.RootFS.ChainID())
+	if err != nil {
+		return "", fmt.Errorf("error getting image layer: %v", err)
+	}
+	defer layer.ReleaseAndLog(daemon.layerStore, l)
+
+	ts, err := l.TarStreamFrom(parentChainID)
+	if err != nil {
+		return "", fmt.Errorf("error getting tar stream to parent: %v", err)
+	}
+	defer ts.Close()
+
+	newL, err := daemon.layerStore.Register(ts, parentChainID)
+	if err != nil {
+		return "", fmt.Errorf("error registering layer: %v", err)
+	}
+	defer layer.ReleaseAndLog(daemon.layerStore, newL)
+
+	newImage := *img
+	newImage.Parent = ""
+	newImage.V1Image.Parent = ""
+
+	rootFS := *parentImg.RootFS
+	rootFS.DiffIDs = append([]layer.DiffID{}, parentImg.RootFS.DiffIDs...)
+	if diffID := newL.DiffID(); diffID != layer.DigestSHA256EmptyTar {
+		rootFS.Append(diffID)
+	}
+	newImage.RootFS = &rootFS
+
+	newImage.History = make([]image.History, len(img.History))
+	for i, h := range img.History {
+		if i >= len(parentImg.History) {
+			h.EmptyLayer = true
+		}
+		newImage.History[i] = h
+	}
+
+	now := time.Now().UTC()
+	var historyComment string
+	if len(parent) > 0 {
+		historyComment = fmt.Sprintf("merge %s to %s", id, parent)
+	} else {
+		historyComment = fmt.Sprintf("create new from %s", id)
+	}
+
+	newImage.History = append(newImage.History, image.History{
+		Created:    now,
+		Comment:    historyComment,
+		EmptyLayer: rootFS.ChainID() == parentChainID,
+	})
+	newImage.Created = now
+
+	b, err := json.Marshal(&newImage)
+	if err != nil {
+		return "", fmt.Errorf("error marshalling image config: %v", err)
+	}
+
+	newImgID, err := daemon.imageStore.Create(b)
+	if err != nil {
+		return "", fmt.Errorf("error creating new image after squash: %v", err)
+	}
+
+	if len(parent) != 0 {
+		if err := daemon.imageStore.SetParent(newImgID, image.ID(parent)); err != nil {
+			return "", err
+		}
+	}
+	return string(newImgID), nil
+}
+
+// squashBase returns the image the squashed layers of a commit are merged
+// on top of. This is the first image in the parent chain of the specified
+// image which was not itself produced by a local build or commit, i.e. the
+// image that was pulled, loaded or imported.
+func (daemon *Daemon) squashBase(id image.ID) image.ID {
+	for {
+		parent, err := daemon.imageStore.GetParent(id)
+		if err != nil || parent == "" {
+			return id
+		}
+		if _, err := daemon.imageStore.Get(parent); err != nil {
+			return id
+		}
+		id = parent
+	}
+}
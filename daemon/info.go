@@ -1,6 +1,7 @@
 package daemon
 
 import (
+	"fmt"
 	"os"
 	"runtime"
 	"sync/atomic"
@@ -17,6 +18,7 @@ import (
 	"github.com/docker/docker/pkg/system"
 	"github.com/docker/docker/registry"
 	"github.com/docker/docker/utils"
+	"github.com/docker/docker/volume"
 	"github.com/docker/docker/volume/drivers"
 	"github.com/docker/engine-api/types"
 	"github.com/docker/go-connections/sockets"
@@ -174,6 +176,7 @@ func (daemon *Daemon) showPluginsInfo() types.PluginsInfo {
 	var pluginsInfo types.PluginsInfo
 
 	pluginsInfo.Volume = volumedrivers.GetDriverList()
+	pluginsInfo.VolumeHealth = volumeDriversHealth(pluginsInfo.Volume)
 
 	networkDriverList := daemon.GetNetworkDriverList()
 	for nd := range networkDriverList {
@@ -184,3 +187,60 @@ func (daemon *Daemon) showPluginsInfo() types.PluginsInfo {
 
 	return pluginsInfo
 }
+
+// volumeHealthTimeout is how long the health check of a volume driver may
+// take before the driver is reported as unhealthy.
+const volumeHealthTimeout = 5 * time.Second
+
+// volumeDriversHealth checks the health of the volume drivers which support
+// health checks, concurrently. A driver which does not answer within
+// volumeHealthTimeout is reported as unhealthy.
+func volumeDriversHealth(names []string) map[string]string {
+	type result struct {
+		name, status string
+	}
+	results := make(chan result, len(names))
+	pending := make(map[string]bool)
+	for _, name := range names {
+		vd, err := volumedrivers.GetDriver(name)
+		if err != nil {
+			continue
+		}
+		hc, ok := vd.(volume.HealthChecker)
+		if !ok {
+			continue
+		}
+		pending[name] = true
+		go func(name string, hc volume.HealthChecker) {
+			switch err := hc.Health(); err {
+			case nil:
+				results <- result{name, "healthy"}
+			case volume.ErrNotSupported:
+				results <- result{name, ""}
+			default:
+				results <- result{name, fmt.Sprintf("unhealthy: %v", err)}
+			}
+		}(name, hc)
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	health := make(map[string]string, len(pending))
+	timeout := time.After(volumeHealthTimeout)
+	for len(pending) > 0 {
+		select {
+		case r := <-results:
+			if r.status != "" {
+				health[r.name] = r.status
+			}
+			delete(pending, r.name)
+		case <-timeout:
+			for name := range pending {
+				health[name] = "unhealthy: health check timed out"
+			}
+			return health
+		}
+	}
+	return health
+}
@@ -200,8 +200,9 @@ func (daemon *Daemon) ContainerExecInspect(id string) (*backend.ExecInspect, err
 }
 
 // VolumeInspect looks up a volume by name. An error is returned if
-// the volume cannot be found.
-func (daemon *Daemon) VolumeInspect(name string) (*types.Volume, error) {
+// the volume cannot be found. The size of the volume data is only
+// computed if size is true, as it requires walking the whole volume.
+func (daemon *Daemon) VolumeInspect(name string, size bool) (*types.Volume, error) {
 	v, err := daemon.volumes.Get(name)
 	if err != nil {
 		return nil, err
@@ -209,6 +210,7 @@ func (daemon *Daemon) VolumeInspect(name string) (*types.Volume, error) {
 	apiV := volumeToAPIType(v)
 	apiV.Mountpoint = v.Path()
 	apiV.Status = v.Status()
+	apiV.UsageData = daemon.volumeUsage(v, size)
 	return apiV, nil
 }
 
@@ -18,9 +18,11 @@ import (
 )
 
 var acceptedVolumeFilterTags = map[string]bool{
-	"dangling": true,
-	"name":     true,
-	"driver":   true,
+	"dangling":  true,
+	"name":      true,
+	"driver":    true,
+	"label":     true,
+	"in-use-by": true,
 }
 
 var acceptedPsFilterTags = map[string]bool{
@@ -532,8 +534,9 @@ func (daemon *Daemon) transformContainer(container *container.Container, ctx *li
 }
 
 // Volumes lists known volumes, using the filter to restrict the range
-// of volumes returned.
-func (daemon *Daemon) Volumes(filter string) ([]*types.Volume, []string, error) {
+// of volumes returned. The size of the volume data is only computed if
+// size is true.
+func (daemon *Daemon) Volumes(filter string, size bool) ([]*types.Volume, []string, error) {
 	var (
 		volumesOut []*types.Volume
 	)
@@ -564,6 +567,7 @@ func (daemon *Daemon) Volumes(filter string) ([]*types.Volume, []string, error)
 		} else {
 			apiV.Mountpoint = v.Path()
 		}
+		apiV.UsageData = daemon.volumeUsage(v, size)
 		volumesOut = append(volumesOut, apiV)
 	}
 	return volumesOut, warnings, nil
@@ -577,6 +581,15 @@ func (daemon *Daemon) filterVolumes(vols []volume.Volume, filter filters.Args) (
 		return vols, nil
 	}
 
+	inUseBy := make(map[string]bool)
+	for _, name := range filter.Get("in-use-by") {
+		c, err := daemon.GetContainer(name)
+		if err != nil {
+			return nil, err
+		}
+		inUseBy[c.ID] = true
+	}
+
 	var retVols []volume.Volume
 	for _, vol := range vols {
 		if filter.Include("name") {
@@ -589,6 +602,24 @@ func (daemon *Daemon) filterVolumes(vols []volume.Volume, filter filters.Args) (
 				continue
 			}
 		}
+		if filter.Include("label") {
+			v, ok := vol.(volume.LabeledVolume)
+			if !ok || !filter.MatchKVList("label", v.Labels()) {
+				continue
+			}
+		}
+		if len(inUseBy) > 0 {
+			var used bool
+			for _, ref := range daemon.volumes.Refs(vol) {
+				if inUseBy[ref] {
+					used = true
+					break
+				}
+			}
+			if !used {
+				continue
+			}
+		}
 		retVols = append(retVols, vol)
 	}
 	danglingOnly := false
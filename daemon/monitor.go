@@ -30,7 +30,8 @@ func (daemon *Daemon) StateChanged(id string, e libcontainerd.StateInfo) error {
 		defer c.Unlock()
 		c.StreamConfig.Wait()
 		c.Reset(false)
-		c.SetStopped(platformConstructExitStatus(e))
+		exitStatus := platformConstructExitStatus(e)
+		c.SetStopped(exitStatus)
 		attributes := map[string]string{
 			"exitCode": strconv.Itoa(int(e.ExitCode)),
 		}
@@ -43,6 +44,7 @@ func (daemon *Daemon) StateChanged(id string, e libcontainerd.StateInfo) error {
 		if err := c.ToDisk(); err != nil {
 			return err
 		}
+		daemon.podContainerExited(c, exitStatus)
 		return daemon.postRunProcessing(c, e)
 	case libcontainerd.StateRestart:
 		c.Lock()
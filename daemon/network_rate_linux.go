@@ -0,0 +1,257 @@
+package daemon
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/container"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+)
+
+const (
+	// networkRateMinBurst is the minimum size of the bucket of the token
+	// bucket filters shaping the network traffic of containers, in bytes.
+	// It holds at least one segmentation offload sized packet.
+	networkRateMinBurst = 64 * 1024
+	// networkRateLatency is the maximum time a packet can be queued by the
+	// token bucket filters, in microseconds.
+	networkRateLatency = 50000
+
+	// vethPrefix is the prefix of the names of the host side of the veth
+	// pairs of containers, and ifbPrefix the prefix the ifb device shaping
+	// the egress traffic of a veth peer is named with instead.
+	vethPrefix = "veth"
+	ifbPrefix  = "ifb-"
+)
+
+// setNetworkRate applies the network rate limits of the container to the
+// host side of the veth pairs of the interfaces of its network namespace,
+// so that they cannot be removed from the container. The ingress traffic
+// is shaped on the veth peers, and the egress traffic on ifb devices the
+// traffic received by the peers is redirected to. A limit that is not set
+// is removed.
+func (daemon *Daemon) setNetworkRate(c *container.Container) error {
+	if c.HostConfig.NetworkMode.IsHost() || c.HostConfig.NetworkMode.IsContainer() || c.NetworkSettings == nil || c.NetworkSettings.SandboxKey == "" {
+		return nil
+	}
+
+	ns, err := netns.GetFromPath(c.NetworkSettings.SandboxKey)
+	if err != nil {
+		return err
+	}
+	defer ns.Close()
+	sbHandle, err := netlink.NewHandleAt(ns)
+	if err != nil {
+		return err
+	}
+	defer sbHandle.Delete()
+	hostHandle, err := netlink.NewHandle()
+	if err != nil {
+		return err
+	}
+	defer hostHandle.Delete()
+
+	links, err := sbHandle.LinkList()
+	if err != nil {
+		return err
+	}
+	for _, link := range links {
+		if link.Attrs().Flags&net.FlagLoopback != 0 {
+			continue
+		}
+
+		peer := vethPeer(hostHandle, link)
+		if peer == nil {
+			if c.HostConfig.NetworkIngressRate > 0 || c.HostConfig.NetworkEgressRate > 0 {
+				logrus.Warnf("Cannot limit the network rate of %s of container %s: the interface has no veth peer on the host", link.Attrs().Name, c.ID)
+			}
+			continue
+		}
+		if err := setLinkRate(hostHandle, peer, c.HostConfig.NetworkIngressRate); err != nil {
+			return fmt.Errorf("failed to limit the ingress rate of %s: %v", link.Attrs().Name, err)
+		}
+		if err := setEgressRate(hostHandle, peer, c.HostConfig.NetworkEgressRate); err != nil {
+			return fmt.Errorf("failed to limit the egress rate of %s: %v", link.Attrs().Name, err)
+		}
+	}
+	return nil
+}
+
+// removeStaleIfbs removes the ifb devices shaping the egress traffic of
+// veth peers which do not exist anymore, such as the peers of the
+// interfaces of stopped containers.
+func removeStaleIfbs() {
+	h, err := netlink.NewHandle()
+	if err != nil {
+		logrus.Warnf("Failed to remove stale ifb devices: %v", err)
+		return
+	}
+	defer h.Delete()
+
+	links, err := h.LinkList()
+	if err != nil {
+		logrus.Warnf("Failed to remove stale ifb devices: %v", err)
+		return
+	}
+	for _, link := range links {
+		name := link.Attrs().Name
+		if link.Type() != "ifb" || !strings.HasPrefix(name, ifbPrefix) {
+			continue
+		}
+		if _, err := h.LinkByName(vethPrefix + strings.TrimPrefix(name, ifbPrefix)); err == nil {
+			continue
+		}
+		if err := h.LinkDel(link); err != nil {
+			logrus.Warnf("Failed to remove stale ifb device %s: %v", name, err)
+		}
+	}
+}
+
+// vethPeer returns the host side of the veth pair of an interface of a
+// container, or nil if the interface is not a veth or its peer is not in
+// the host network namespace (for example, on overlay networks).
+func vethPeer(h *netlink.Handle, link netlink.Link) netlink.Link {
+	if link.Type() != "veth" || link.Attrs().ParentIndex == 0 {
+		return nil
+	}
+	peer, err := h.LinkByIndex(link.Attrs().ParentIndex)
+	if err != nil || peer.Type() != "veth" || peer.Attrs().ParentIndex != link.Attrs().Index {
+		return nil
+	}
+	return peer
+}
+
+// setLinkRate shapes the traffic sent on the link to rate bytes per second
+// with a token bucket filter, or removes the filter if rate is not positive.
+func setLinkRate(h *netlink.Handle, link netlink.Link, rate int64) error {
+	if rate <= 0 {
+		qdiscs, err := h.QdiscList(link)
+		if err != nil {
+			return err
+		}
+		for _, q := range qdiscs {
+			if q.Type() == "tbf" && q.Attrs().Parent == netlink.HANDLE_ROOT {
+				return h.QdiscDel(q)
+			}
+		}
+		return nil
+	}
+
+	burst := uint32(rate / 100)
+	if burst < networkRateMinBurst {
+		burst = networkRateMinBurst
+	}
+	tbf := &netlink.Tbf{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: link.Attrs().Index,
+			Handle:    netlink.MakeHandle(1, 0),
+			Parent:    netlink.HANDLE_ROOT,
+		},
+		Rate:   uint64(rate),
+		Limit:  uint32(rate*networkRateLatency/1000000) + burst,
+		Buffer: uint32(netlink.Xmittime(uint64(rate), burst)),
+	}
+	return h.QdiscReplace(tbf)
+}
+
+// setEgressRate shapes the traffic received by the host side of the veth
+// pair of an interface of a container, that is the traffic the container
+// sends, to rate bytes per second. The traffic is redirected to an ifb
+// device, on which a token bucket filter shapes it, as received traffic
+// cannot be queued. The ifb device is removed if rate is not positive, or
+// if the traffic cannot be redirected to it.
+func setEgressRate(h *netlink.Handle, peer netlink.Link, rate int64) (err error) {
+	name := ifbPrefix + strings.TrimPrefix(peer.Attrs().Name, vethPrefix)
+	if len(name) > syscall.IFNAMSIZ-1 {
+		name = name[:syscall.IFNAMSIZ-1]
+	}
+	ingress := &netlink.Ingress{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: peer.Attrs().Index,
+			Handle:    netlink.MakeHandle(0xffff, 0),
+			Parent:    netlink.HANDLE_INGRESS,
+		},
+	}
+
+	if rate <= 0 {
+		qdiscs, err := h.QdiscList(peer)
+		if err != nil {
+			return err
+		}
+		for _, q := range qdiscs {
+			if q.Type() == "ingress" {
+				if err := h.QdiscDel(ingress); err != nil {
+					return err
+				}
+				break
+			}
+		}
+		if ifb, err := h.LinkByName(name); err == nil {
+			return h.LinkDel(ifb)
+		}
+		return nil
+	}
+
+	ifb, err := h.LinkByName(name)
+	if err != nil {
+		attrs := netlink.NewLinkAttrs()
+		attrs.Name = name
+		if err := h.LinkAdd(&netlink.Ifb{LinkAttrs: attrs}); err != nil {
+			return err
+		}
+		if ifb, err = h.LinkByName(name); err != nil {
+			return err
+		}
+		defer func() {
+			if err == nil {
+				return
+			}
+			if delErr := h.LinkDel(ifb); delErr != nil {
+				logrus.Warnf("Failed to remove ifb device %s: %v", name, delErr)
+			}
+		}()
+	}
+	if err := h.LinkSetUp(ifb); err != nil {
+		return err
+	}
+	if err := setLinkRate(h, ifb, rate); err != nil {
+		return err
+	}
+
+	if err := h.QdiscReplace(ingress); err != nil {
+		return err
+	}
+	filters, err := h.FilterList(peer, ingress.Handle)
+	if err != nil {
+		return err
+	}
+	for _, f := range filters {
+		if u32, ok := f.(*netlink.U32); ok && len(u32.Actions) > 0 {
+			if mirred, ok := u32.Actions[0].(*netlink.MirredAction); ok && mirred.Ifindex == ifb.Attrs().Index {
+				return nil
+			}
+		}
+	}
+	// drop the filters redirecting to a previous ifb device
+	if len(filters) > 0 {
+		if err := h.QdiscDel(ingress); err != nil {
+			return err
+		}
+		if err := h.QdiscAdd(ingress); err != nil {
+			return err
+		}
+	}
+	return h.FilterAdd(&netlink.U32{
+		FilterAttrs: netlink.FilterAttrs{
+			LinkIndex: peer.Attrs().Index,
+			Parent:    ingress.Handle,
+			Priority:  1,
+			Protocol:  syscall.ETH_P_ALL,
+		},
+		Actions: []netlink.Action{netlink.NewMirredAction(ifb.Attrs().Index)},
+	})
+}
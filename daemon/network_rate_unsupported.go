@@ -0,0 +1,12 @@
+// +build !linux
+
+package daemon
+
+import "github.com/docker/docker/container"
+
+func (daemon *Daemon) setNetworkRate(c *container.Container) error {
+	return nil
+}
+
+func removeStaleIfbs() {
+}
@@ -0,0 +1,313 @@
+package daemon
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/errors"
+	"github.com/docker/engine-api/types"
+	"github.com/docker/go-connections/nat"
+	"github.com/docker/libnetwork"
+	"github.com/docker/libnetwork/ipamapi"
+	"github.com/docker/libnetwork/portallocator"
+)
+
+// maxCheckedPorts is the maximum number of ports CheckPorts checks at once.
+const maxCheckedPorts = 1024
+
+// portSpec is a range of host ports to check, parsed from
+// [IP:]PORT[-END][/PROTO].
+type portSpec struct {
+	ip         net.IP
+	start, end int
+	proto      string
+}
+
+type byHostPort []types.PortReservation
+
+func (r byHostPort) Len() int      { return len(r) }
+func (r byHostPort) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r byHostPort) Less(i, j int) bool {
+	if r[i].PublicPort != r[j].PublicPort {
+		return r[i].PublicPort < r[j].PublicPort
+	}
+	if r[i].Type != r[j].Type {
+		return r[i].Type < r[j].Type
+	}
+	return r[i].IP < r[j].IP
+}
+
+// NetworkUsage returns the addresses in use in the IPAM pools of the networks
+// and the host ports published by the running containers.
+func (daemon *Daemon) NetworkUsage() (*types.NetworkUsage, error) {
+	usage := &types.NetworkUsage{
+		Networks: []types.NetworkPoolUsage{},
+		Ports:    daemon.PortReservations(),
+	}
+	if !daemon.NetworkControllerEnabled() {
+		return usage, nil
+	}
+	for _, nw := range daemon.GetNetworks() {
+		usage.Networks = append(usage.Networks, daemon.networkPoolUsage(nw))
+	}
+	return usage, nil
+}
+
+// networkPoolUsage returns the addresses reserved in the IPAM pools of the
+// network and allocated to its endpoints. If the IPAM driver of the network
+// can report the state of its pools, the gateways and auxiliary addresses
+// are only returned if the driver holds them, and the number of addresses
+// still available is returned.
+func (daemon *Daemon) networkPoolUsage(nw libnetwork.Network) types.NetworkPoolUsage {
+	info := nw.Info()
+	u := types.NetworkPoolUsage{
+		Name:   nw.Name(),
+		ID:     nw.ID(),
+		Driver: nw.Type(),
+		Scope:  info.Scope(),
+		Pools:  []types.IPAMPoolUsage{},
+	}
+
+	ipamType, _, ipv4conf, ipv6conf := info.IpamConfig()
+	ipv4Info, ipv6Info := info.IpamInfo()
+
+	var querier ipamapi.Querier
+	if ipam, err := daemon.netController.IpamDriver(ipamType); err != nil {
+		logrus.Warnf("Failed to retrieve the IPAM driver %s of network %s: %v", ipamType, nw.Name(), err)
+	} else {
+		querier, _ = ipam.(ipamapi.Querier)
+	}
+
+	var subnets []*net.IPNet
+	for _, pools := range []struct {
+		info []*libnetwork.IpamInfo
+		conf []*libnetwork.IpamConf
+	}{{ipv4Info, ipv4conf}, {ipv6Info, ipv6conf}} {
+		for _, pi := range pools.info {
+			if pi.Pool == nil {
+				continue
+			}
+			pool := types.IPAMPoolUsage{
+				Subnet:    pi.Pool.String(),
+				Allocated: []types.AddressAllocation{},
+			}
+
+			q := querier
+			if q != nil {
+				size, available, err := q.PoolUsage(pi.PoolID)
+				if err != nil {
+					// The pools of the swarm scoped networks are managed
+					// by the manager nodes, not by the local IPAM driver.
+					logrus.Debugf("Failed to query pool %s of network %s: %v", pool.Subnet, nw.Name(), err)
+					q = nil
+				} else {
+					pool.Size, pool.Available = size, available
+				}
+			}
+
+			if pi.Gateway != nil && isAddressAllocated(q, pi.PoolID, pi.Gateway.IP) {
+				pool.Gateway = pi.Gateway.IP.String()
+			}
+			for name, aux := range pi.AuxAddresses {
+				if aux == nil || !isAddressAllocated(q, pi.PoolID, aux.IP) {
+					continue
+				}
+				if pool.Reserved == nil {
+					pool.Reserved = make(map[string]string)
+				}
+				pool.Reserved[name] = aux.IP.String()
+			}
+			for _, conf := range pools.conf {
+				if conf.PreferredPool == pool.Subnet {
+					pool.IPRange = conf.SubPool
+				}
+			}
+			u.Pools = append(u.Pools, pool)
+			subnets = append(subnets, pi.Pool)
+		}
+	}
+
+	for _, e := range nw.Endpoints() {
+		ei := e.Info()
+		if ei == nil || ei.Iface() == nil {
+			continue
+		}
+		containerID := ""
+		if sb := ei.Sandbox(); sb != nil {
+			containerID = sb.ContainerID()
+		}
+		for _, addr := range []*net.IPNet{ei.Iface().Address(), ei.Iface().AddressIPv6()} {
+			if addr == nil || len(addr.IP) == 0 {
+				continue
+			}
+			for i, subnet := range subnets {
+				if subnet.Contains(addr.IP) {
+					u.Pools[i].Allocated = append(u.Pools[i].Allocated, types.AddressAllocation{
+						Address:     addr.IP.String(),
+						EndpointID:  e.ID(),
+						Name:        e.Name(),
+						ContainerID: containerID,
+					})
+					break
+				}
+			}
+		}
+	}
+	return u
+}
+
+// isAddressAllocated returns whether the IPAM driver holds the address in the
+// pool. Addresses the driver cannot report on are assumed to be allocated.
+func isAddressAllocated(q ipamapi.Querier, poolID string, address net.IP) bool {
+	if q == nil {
+		return true
+	}
+	allocated, err := q.IsAddressAllocated(poolID, address)
+	return err != nil || allocated
+}
+
+// PortReservations returns the host ports published by the running
+// containers, ordered by port.
+func (daemon *Daemon) PortReservations() []types.PortReservation {
+	reservations := []types.PortReservation{}
+	for _, c := range daemon.List() {
+		c.Lock()
+		if c.Running && c.NetworkSettings != nil {
+			for p, bindings := range c.NetworkSettings.Ports {
+				for _, b := range bindings {
+					hostPort, err := strconv.Atoi(b.HostPort)
+					if err != nil {
+						continue
+					}
+					reservations = append(reservations, types.PortReservation{
+						Port: types.Port{
+							IP:          b.HostIP,
+							PrivatePort: p.Int(),
+							PublicPort:  hostPort,
+							Type:        p.Proto(),
+						},
+						ContainerID:   c.ID,
+						ContainerName: strings.TrimPrefix(c.Name, "/"),
+					})
+				}
+			}
+		}
+		c.Unlock()
+	}
+	sort.Sort(byHostPort(reservations))
+	return reservations
+}
+
+// CheckPorts checks whether the host ports are available to publish. A port
+// is not available if a running container publishes it, or if the port
+// allocator already allocated it, on an overlapping address. Ports used by
+// other processes on the host are not detected. At most maxCheckedPorts
+// ports are checked.
+func (daemon *Daemon) CheckPorts(ports []string) ([]types.PortCheckResult, error) {
+	var (
+		specs []portSpec
+		count int
+	)
+	for _, p := range ports {
+		spec, err := parsePortSpec(p)
+		if err != nil {
+			return nil, errors.NewBadRequestError(err)
+		}
+		if count += spec.end - spec.start + 1; count > maxCheckedPorts {
+			return nil, errors.NewBadRequestError(fmt.Errorf("too many ports to check, at most %d ports can be checked at once", maxCheckedPorts))
+		}
+		specs = append(specs, spec)
+	}
+
+	reservations := daemon.PortReservations()
+	allocator := portallocator.Get()
+	results := []types.PortCheckResult{}
+	for _, spec := range specs {
+		for port := spec.start; port <= spec.end; port++ {
+			result := types.PortCheckResult{
+				Port: types.Port{
+					IP:         spec.ip.String(),
+					PublicPort: port,
+					Type:       spec.proto,
+				},
+				Available: true,
+			}
+			if r := findPortReservation(reservations, spec.ip, port, spec.proto); r != nil {
+				result.Available = false
+				result.PrivatePort = r.PrivatePort
+				result.ContainerID = r.ContainerID
+				result.ContainerName = r.ContainerName
+				result.Reason = fmt.Sprintf("port is published by container %s", r.ContainerName)
+			} else if ip := findAllocatedIP(allocator.AllocatedIPs(spec.proto, port), spec.ip); ip != nil {
+				result.Available = false
+				result.Reason = fmt.Sprintf("port is already allocated on %s", ip)
+			}
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+// parsePortSpec parses a range of host ports in the [IP:]PORT[-END][/PROTO]
+// format. IPv6 addresses are enclosed in square brackets. The ports are
+// checked on all the addresses and for TCP if not specified.
+func parsePortSpec(raw string) (portSpec, error) {
+	spec := portSpec{ip: net.IPv4zero}
+
+	proto, hostPort := nat.SplitProtoPort(raw)
+	if proto != "tcp" && proto != "udp" {
+		return spec, fmt.Errorf("invalid port %q: unsupported protocol %q", raw, proto)
+	}
+	spec.proto = proto
+
+	if strings.Contains(hostPort, ":") {
+		host, port, err := net.SplitHostPort(hostPort)
+		if err != nil {
+			return spec, fmt.Errorf("invalid port %q: %v", raw, err)
+		}
+		if spec.ip = net.ParseIP(host); spec.ip == nil {
+			return spec, fmt.Errorf("invalid port %q: invalid IP address %q", raw, host)
+		}
+		hostPort = port
+	}
+
+	start, end, err := nat.ParsePortRange(hostPort)
+	if err != nil {
+		return spec, fmt.Errorf("invalid port %q: %v", raw, err)
+	}
+	if start == 0 {
+		return spec, fmt.Errorf("invalid port %q: ports start at 1", raw)
+	}
+	spec.start, spec.end = int(start), int(end)
+	return spec, nil
+}
+
+// findPortReservation returns the reservation of the port on an address
+// overlapping ip, or nil if the port is not published by any container.
+func findPortReservation(reservations []types.PortReservation, ip net.IP, port int, proto string) *types.PortReservation {
+	for i, r := range reservations {
+		if r.PublicPort != port || r.Type != proto {
+			continue
+		}
+		rip := net.ParseIP(r.IP)
+		if rip == nil || rip.IsUnspecified() || ip.IsUnspecified() || rip.Equal(ip) {
+			return &reservations[i]
+		}
+	}
+	return nil
+}
+
+// findAllocatedIP returns the first of the addresses a port is allocated on
+// which overlaps ip, or nil if there is none.
+func findAllocatedIP(allocated []net.IP, ip net.IP) net.IP {
+	for _, a := range allocated {
+		if a.IsUnspecified() || ip.IsUnspecified() || a.Equal(ip) {
+			return a
+		}
+	}
+	return nil
+}
@@ -320,6 +320,14 @@ func setNamespaces(daemon *Daemon, s *specs.Spec, c *container.Container) error
 	if c.HostConfig.UTSMode.IsHost() {
 		delNamespace(s, specs.NamespaceType("uts"))
 		s.Hostname = ""
+	} else if c.HostConfig.Pod != "" {
+		// containers of a pod share the UTS namespace of its infra container
+		uc, err := daemon.getNetworkedContainer(c.ID, c.HostConfig.NetworkMode.ConnectedContainer())
+		if err != nil {
+			return err
+		}
+		setNamespace(s, specs.Namespace{Type: "uts", Path: fmt.Sprintf("/proc/%d/ns/uts", uc.State.GetPID())})
+		s.Hostname = ""
 	}
 
 	return nil
@@ -0,0 +1,347 @@
+package daemon
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/errors"
+	"github.com/docker/docker/pkg/namesgenerator"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/docker/pod"
+	"github.com/docker/engine-api/types"
+	containertypes "github.com/docker/engine-api/types/container"
+	"github.com/docker/engine-api/types/strslice"
+	"github.com/docker/go-connections/nat"
+)
+
+// PodCreate creates a pod, along with its infra container holding the
+// namespaces the containers of the pod join.
+func (daemon *Daemon) PodCreate(req types.PodCreateRequest) (*types.PodCreateResponse, error) {
+	if runtime.GOOS == "windows" {
+		return nil, fmt.Errorf("pods are not supported on Windows")
+	}
+	if req.InfraImage == "" {
+		return nil, errors.NewBadRequestError(fmt.Errorf("an infra image is required to create a pod"))
+	}
+
+	name := req.Name
+	if name == "" {
+		name = namesgenerator.GetRandomName(0)
+	} else if !validContainerNamePattern.MatchString(name) {
+		return nil, errors.NewBadRequestError(fmt.Errorf("Invalid pod name (%s), only %s are allowed", name, validContainerNameChars))
+	}
+	if _, err := daemon.pods.Get(name); err == nil {
+		return nil, errors.NewRequestConflictError(fmt.Errorf("The pod name %q is already in use", name))
+	}
+
+	// the containers of the pod share the network namespace of the infra
+	// container, which publishes the ports of the pod
+	exposedPorts := make(map[nat.Port]struct{}, len(req.PortBindings))
+	for port := range req.PortBindings {
+		exposedPorts[port] = struct{}{}
+	}
+	resp, err := daemon.containerCreate(types.ContainerCreateConfig{
+		Name: name + "-infra",
+		Config: &containertypes.Config{
+			Image:        req.InfraImage,
+			Cmd:          strslice.StrSlice(req.InfraCommand),
+			ExposedPorts: exposedPorts,
+		},
+		HostConfig: &containertypes.HostConfig{
+			PortBindings: req.PortBindings,
+		},
+	}, false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &pod.Pod{
+		ID:               stringid.GenerateRandomID(),
+		Name:             name,
+		Created:          time.Now().UTC(),
+		Labels:           req.Labels,
+		InfraContainerID: resp.ID,
+	}
+	if err := daemon.pods.Add(p); err != nil {
+		if rmErr := daemon.ContainerRm(resp.ID, &types.ContainerRmConfig{ForceRemove: true}); rmErr != nil {
+			logrus.Errorf("Error removing the infra container of pod %s: %v", name, rmErr)
+		}
+		if err == pod.ErrNameConflict {
+			return nil, errors.NewRequestConflictError(fmt.Errorf("The pod name %q is already in use", name))
+		}
+		return nil, err
+	}
+	return &types.PodCreateResponse{ID: p.ID}, nil
+}
+
+// PodStart starts the infra container of the pod, and then the containers
+// of the pod that are not running.
+func (daemon *Daemon) PodStart(name string) error {
+	p, err := daemon.GetPod(name)
+	if err != nil {
+		return err
+	}
+	infra, err := daemon.GetContainer(p.InfraContainerID)
+	if err != nil {
+		return err
+	}
+	if err := daemon.containerStart(infra); err != nil {
+		return fmt.Errorf("Cannot start the infra container of pod %s: %v", p.Name, err)
+	}
+
+	var failed []string
+	for _, c := range daemon.podContainers(p) {
+		if c.IsRunning() {
+			continue
+		}
+		c.RestartManager(true)
+		if err := daemon.containerStart(c); err != nil {
+			logrus.Errorf("Error starting container %s of pod %s: %v", c.ID, p.Name, err)
+			failed = append(failed, c.ID)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("Cannot start containers of pod %s: %v", p.Name, failed)
+	}
+	return nil
+}
+
+// PodStop stops the containers of the pod, and then its infra container.
+func (daemon *Daemon) PodStop(name string, seconds int) error {
+	p, err := daemon.GetPod(name)
+	if err != nil {
+		return err
+	}
+
+	var failed []string
+	for _, c := range daemon.podContainers(p) {
+		if err := daemon.containerStop(c, seconds); err != nil {
+			logrus.Errorf("Error stopping container %s of pod %s: %v", c.ID, p.Name, err)
+			failed = append(failed, c.ID)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("Cannot stop containers of pod %s: %v", p.Name, failed)
+	}
+
+	infra, err := daemon.GetContainer(p.InfraContainerID)
+	if err != nil {
+		return err
+	}
+	if err := daemon.containerStop(infra, seconds); err != nil {
+		return fmt.Errorf("Cannot stop the infra container of pod %s: %v", p.Name, err)
+	}
+	return nil
+}
+
+// PodRm removes the pod and its infra container. The containers of the pod
+// are removed if force is set, and the removal fails otherwise.
+func (daemon *Daemon) PodRm(name string, force bool) error {
+	p, err := daemon.GetPod(name)
+	if err != nil {
+		return err
+	}
+
+	members := daemon.podContainers(p)
+	if len(members) > 0 && !force {
+		return errors.NewRequestConflictError(fmt.Errorf("Unable to remove pod %s, it has %d containers. Remove them first or use force", p.Name, len(members)))
+	}
+	for _, c := range members {
+		if err := daemon.ContainerRm(c.ID, &types.ContainerRmConfig{ForceRemove: true}); err != nil {
+			return fmt.Errorf("Cannot remove container %s of pod %s: %v", c.ID, p.Name, err)
+		}
+	}
+
+	// the pod is deleted first so that its infra container can be removed
+	if err := daemon.pods.Delete(p.ID); err != nil {
+		return err
+	}
+	if err := daemon.ContainerRm(p.InfraContainerID, &types.ContainerRmConfig{ForceRemove: true}); err != nil {
+		return fmt.Errorf("Cannot remove the infra container of pod %s: %v", p.Name, err)
+	}
+	return nil
+}
+
+// PodInspect returns the pod with the given name or ID.
+func (daemon *Daemon) PodInspect(name string) (*types.Pod, error) {
+	p, err := daemon.GetPod(name)
+	if err != nil {
+		return nil, err
+	}
+	return daemon.podToAPIType(p), nil
+}
+
+// Pods returns the pods of the daemon, ordered by name.
+func (daemon *Daemon) Pods() ([]*types.Pod, error) {
+	pods := daemon.pods.List()
+	sort.Sort(byPodName(pods))
+	apiPods := make([]*types.Pod, 0, len(pods))
+	for _, p := range pods {
+		apiPods = append(apiPods, daemon.podToAPIType(p))
+	}
+	return apiPods, nil
+}
+
+// GetPod returns the pod with the given name, ID or ID prefix.
+func (daemon *Daemon) GetPod(name string) (*pod.Pod, error) {
+	p, err := daemon.pods.Get(name)
+	if err == pod.ErrNotFound {
+		return nil, errors.NewRequestNotFoundError(fmt.Errorf("No such pod: %s", name))
+	}
+	return p, err
+}
+
+func (daemon *Daemon) podToAPIType(p *pod.Pod) *types.Pod {
+	apiPod := &types.Pod{
+		ID:               p.ID,
+		Name:             p.Name,
+		Created:          p.Created.Format(time.RFC3339Nano),
+		Labels:           p.Labels,
+		InfraContainerID: p.InfraContainerID,
+		Containers:       []string{},
+	}
+	if infra, err := daemon.GetContainer(p.InfraContainerID); err == nil {
+		apiPod.Running = infra.IsRunning()
+	}
+	for _, c := range daemon.podContainers(p) {
+		apiPod.Containers = append(apiPod.Containers, c.ID)
+	}
+	return apiPod
+}
+
+// podContainers returns the containers of the pod, excluding its infra
+// container.
+func (daemon *Daemon) podContainers(p *pod.Pod) []*container.Container {
+	var containers []*container.Container
+	for _, c := range daemon.containers.List() {
+		if c.HostConfig.Pod == p.ID {
+			containers = append(containers, c)
+		}
+	}
+	return containers
+}
+
+// joinPod resolves the pod of a container being created, and sets the
+// container to join the namespaces of the infra container of the pod.
+func (daemon *Daemon) joinPod(hostConfig *containertypes.HostConfig) error {
+	p, err := daemon.GetPod(hostConfig.Pod)
+	if err != nil {
+		return err
+	}
+	if !(hostConfig.NetworkMode == "" || hostConfig.NetworkMode.IsDefault()) || hostConfig.IpcMode != "" || hostConfig.PidMode != "" || hostConfig.UTSMode != "" {
+		return errors.NewBadRequestError(fmt.Errorf("Conflicting options: a container of a pod cannot set the network, IPC, PID or UTS mode"))
+	}
+	if len(hostConfig.PortBindings) > 0 || hostConfig.PublishAllPorts {
+		return errors.NewBadRequestError(fmt.Errorf("Conflicting options: a container of a pod cannot publish ports, they are published when creating the pod"))
+	}
+
+	mode := "container:" + p.InfraContainerID
+	hostConfig.Pod = p.ID
+	hostConfig.NetworkMode = containertypes.NetworkMode(mode)
+	hostConfig.IpcMode = containertypes.IpcMode(mode)
+	hostConfig.PidMode = containertypes.PidMode(mode)
+	return nil
+}
+
+// startPodInfra starts the infra container of the pod of a container, if
+// it is not running, so that the container can join its namespaces.
+func (daemon *Daemon) startPodInfra(c *container.Container) error {
+	p, err := daemon.GetPod(c.HostConfig.Pod)
+	if err != nil {
+		return err
+	}
+	infra, err := daemon.GetContainer(p.InfraContainerID)
+	if err != nil {
+		return err
+	}
+	if err := daemon.containerStart(infra); err != nil {
+		return fmt.Errorf("Cannot start the infra container of pod %s: %v", p.Name, err)
+	}
+	return nil
+}
+
+// podContainerExited handles the exit of a container of a pod, or of the
+// infra container of a pod. It is called with the container locked.
+//
+// Containers of a pod are restarted by the daemon rather than by
+// libcontainerd, which would reuse the namespaces the container joined on
+// its first start, so that they join the namespaces of the infra container
+// again. They are marked restarting until they are restarted, so that
+// stopping, killing or removing them cancels the restart. When the infra
+// container exits, the running containers of the pod are killed, so that
+// they do not keep running in stale namespaces and join the new namespaces
+// of the pod if they are restarted.
+func (daemon *Daemon) podContainerExited(c *container.Container, exitStatus *container.ExitStatus) {
+	if daemon.IsShuttingDown() {
+		return
+	}
+
+	if c.HostConfig.Pod != "" {
+		restart, wait, err := c.RestartManager(false).ShouldRestart(uint32(exitStatus.ExitCode), c.HasBeenManuallyStopped, c.FinishedAt.Sub(c.StartedAt))
+		if err != nil || !restart {
+			return
+		}
+		c.RestartCount++
+		c.SetRestarting(exitStatus)
+		if err := c.ToDisk(); err != nil {
+			logrus.Errorf("Error saving container %s of pod: %v", c.ID, err)
+		}
+		go daemon.restartPodContainer(c, exitStatus, wait)
+		return
+	}
+
+	p := daemon.pods.GetByInfraContainer(c.ID)
+	if p == nil {
+		return
+	}
+	go func() {
+		for _, member := range daemon.podContainers(p) {
+			if !member.IsRunning() {
+				continue
+			}
+			if err := daemon.kill(member, int(syscall.SIGKILL)); err != nil {
+				logrus.Errorf("Error killing container %s of pod %s: %v", member.ID, p.Name, err)
+			}
+		}
+	}()
+}
+
+// restartPodContainer restarts a container of a pod once wait is closed,
+// unless the restart was canceled or the container stopped restarting in
+// the meantime.
+func (daemon *Daemon) restartPodContainer(c *container.Container, exitStatus *container.ExitStatus, wait chan error) {
+	err := <-wait
+
+	c.Lock()
+	if !c.Restarting {
+		c.Unlock()
+		return
+	}
+	if err != nil {
+		c.SetStopped(exitStatus)
+		if err := c.ToDisk(); err != nil {
+			logrus.Errorf("Error saving container %s of pod: %v", c.ID, err)
+		}
+		c.Unlock()
+		return
+	}
+	// containerStart only starts containers which are not running
+	c.Running = false
+	c.Restarting = false
+	c.Unlock()
+
+	if err := daemon.containerStart(c); err != nil {
+		logrus.Errorf("Error restarting container %s of pod: %v", c.ID, err)
+	}
+}
+
+type byPodName []*pod.Pod
+
+func (p byPodName) Len() int           { return len(p) }
+func (p byPodName) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+func (p byPodName) Less(i, j int) bool { return p[i].Name < p[j].Name }
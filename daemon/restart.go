@@ -39,6 +39,12 @@ func (daemon *Daemon) containerRestart(container *container.Container, seconds i
 		return err
 	}
 
+	if container.HostConfig.Pod != "" {
+		// stopping the container canceled the restart manager used by the
+		// daemon to restart the containers of a pod
+		container.RestartManager(true)
+	}
+
 	if err := daemon.containerStart(container); err != nil {
 		return err
 	}
@@ -77,6 +77,12 @@ func (daemon *Daemon) ContainerStart(name string, hostConfig *containertypes.Hos
 		return err
 	}
 
+	if container.HostConfig.Pod != "" {
+		// containers of a pod do not reset their restart policy on start, as
+		// they are restarted through containerStart
+		container.RestartManager(true)
+	}
+
 	return daemon.containerStart(container)
 }
 
@@ -115,6 +121,12 @@ func (daemon *Daemon) containerStart(container *container.Container) (err error)
 		}
 	}()
 
+	if container.HostConfig.Pod != "" {
+		if err := daemon.startPodInfra(container); err != nil {
+			return err
+		}
+	}
+
 	if err := daemon.conditionalMountOnStart(container); err != nil {
 		return err
 	}
@@ -132,7 +144,11 @@ func (daemon *Daemon) containerStart(container *container.Container) (err error)
 		return err
 	}
 
-	createOptions := []libcontainerd.CreateOption{libcontainerd.WithRestartManager(container.RestartManager(true))}
+	var createOptions []libcontainerd.CreateOption
+	// containers of a pod are restarted by the daemon, see podContainerExited
+	if container.HostConfig.Pod == "" {
+		createOptions = append(createOptions, libcontainerd.WithRestartManager(container.RestartManager(true)))
+	}
 	copts, err := daemon.getLibcontainerdCreateOptions(container)
 	if err != nil {
 		return err
@@ -145,27 +145,27 @@ func (daemon *Daemon) GetContainerStats(container *container.Container) (*types.
 	return stats, nil
 }
 
-// Resolve Network SandboxID in case the container reuse another container's network stack
-func (daemon *Daemon) getNetworkSandboxID(c *container.Container) (string, error) {
+// Resolve the container owning the network stack in case the container reuse another container's network stack
+func (daemon *Daemon) getNetworkContainer(c *container.Container) (*container.Container, error) {
 	curr := c
 	for curr.HostConfig.NetworkMode.IsContainer() {
 		containerID := curr.HostConfig.NetworkMode.ConnectedContainer()
 		connected, err := daemon.GetContainer(containerID)
 		if err != nil {
-			return "", fmt.Errorf("Could not get container for %s", containerID)
+			return nil, fmt.Errorf("Could not get container for %s", containerID)
 		}
 		curr = connected
 	}
-	return curr.NetworkSettings.SandboxID, nil
+	return curr, nil
 }
 
 func (daemon *Daemon) getNetworkStats(c *container.Container) (map[string]types.NetworkStats, error) {
-	sandboxID, err := daemon.getNetworkSandboxID(c)
+	nc, err := daemon.getNetworkContainer(c)
 	if err != nil {
 		return nil, err
 	}
 
-	sb, err := daemon.netController.SandboxByID(sandboxID)
+	sb, err := daemon.netController.SandboxByID(nc.NetworkSettings.SandboxID)
 	if err != nil {
 		return nil, err
 	}
@@ -175,18 +175,29 @@ func (daemon *Daemon) getNetworkStats(c *container.Container) (map[string]types.
 		return nil, err
 	}
 
+	// the rate limits apply to all the interfaces of the network stack
+	var rxRateLimit, txRateLimit uint64
+	if nc.HostConfig.NetworkIngressRate > 0 {
+		rxRateLimit = uint64(nc.HostConfig.NetworkIngressRate)
+	}
+	if nc.HostConfig.NetworkEgressRate > 0 {
+		txRateLimit = uint64(nc.HostConfig.NetworkEgressRate)
+	}
+
 	stats := make(map[string]types.NetworkStats)
 	// Convert libnetwork nw stats into engine-api stats
 	for ifName, ifStats := range lnstats {
 		stats[ifName] = types.NetworkStats{
-			RxBytes:   ifStats.RxBytes,
-			RxPackets: ifStats.RxPackets,
-			RxErrors:  ifStats.RxErrors,
-			RxDropped: ifStats.RxDropped,
-			TxBytes:   ifStats.TxBytes,
-			TxPackets: ifStats.TxPackets,
-			TxErrors:  ifStats.TxErrors,
-			TxDropped: ifStats.TxDropped,
+			RxBytes:     ifStats.RxBytes,
+			RxPackets:   ifStats.RxPackets,
+			RxErrors:    ifStats.RxErrors,
+			RxDropped:   ifStats.RxDropped,
+			RxRateLimit: rxRateLimit,
+			TxBytes:     ifStats.TxBytes,
+			TxPackets:   ifStats.TxPackets,
+			TxErrors:    ifStats.TxErrors,
+			TxDropped:   ifStats.TxDropped,
+			TxRateLimit: txRateLimit,
 		}
 	}
 
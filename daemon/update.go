@@ -65,6 +65,11 @@ func (daemon *Daemon) update(name string, hostConfig *container.HostConfig) erro
 		return errCannotUpdate(container.ID, fmt.Errorf("Can not update kernel memory to a running container, please stop it first."))
 	}
 
+	networkRateChanged := hostConfig.NetworkIngressRate != 0 || hostConfig.NetworkEgressRate != 0
+	if networkRateChanged && (container.HostConfig.NetworkMode.IsHost() || container.HostConfig.NetworkMode.IsContainer()) {
+		return errCannotUpdate(container.ID, fmt.Errorf("Can not limit the network rate of a container using the host or container network modes."))
+	}
+
 	if err := container.UpdateContainer(hostConfig); err != nil {
 		restoreConfig = true
 		return errCannotUpdate(container.ID, err)
@@ -82,6 +87,12 @@ func (daemon *Daemon) update(name string, hostConfig *container.HostConfig) erro
 			restoreConfig = true
 			return errCannotUpdate(container.ID, err)
 		}
+		if networkRateChanged {
+			if err := daemon.setNetworkRate(container); err != nil {
+				restoreConfig = true
+				return errCannotUpdate(container.ID, err)
+			}
+		}
 	}
 
 	daemon.LogContainerEvent(container, "update")
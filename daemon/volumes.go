@@ -5,10 +5,17 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/container"
+	derr "github.com/docker/docker/errors"
+	"github.com/docker/docker/pkg/directory"
 	"github.com/docker/docker/volume"
+	volumedrivers "github.com/docker/docker/volume/drivers"
 	"github.com/docker/engine-api/types"
 	containertypes "github.com/docker/engine-api/types/container"
 )
@@ -31,12 +38,49 @@ func volumeToAPIType(v volume.Volume) *types.Volume {
 		tv.Labels = v.Labels()
 	}
 
+	if v, ok := v.(volume.DetailedVolume); ok {
+		tv.Options = v.Options()
+	}
+
 	if v, ok := v.(volume.ScopedVolume); ok {
 		tv.Scope = v.Scope()
 	}
 	return tv
 }
 
+// volumeUsage returns how the volume is used by containers. The size of
+// the volume data is only computed if size is true, and only for volumes
+// of the local driver, whose data lives under the volume path.
+func (daemon *Daemon) volumeUsage(v volume.Volume, size bool) *types.VolumeUsageData {
+	seen := make(map[string]bool)
+	containers := []string{}
+	for _, ref := range daemon.volumes.Refs(v) {
+		if !seen[ref] {
+			seen[ref] = true
+			containers = append(containers, ref)
+		}
+	}
+	sort.Strings(containers)
+
+	usage := &types.VolumeUsageData{
+		Size:       -1,
+		RefCount:   len(containers),
+		Containers: containers,
+	}
+	if lastUsed := daemon.volumes.LastUsed(v); !lastUsed.IsZero() {
+		usage.LastUsed = lastUsed.Format(time.RFC3339Nano)
+	}
+	if size && v.DriverName() == volume.DefaultDriverName {
+		s, err := directory.Size(v.Path())
+		if err != nil {
+			logrus.Debugf("Unable to compute size of volume %s: %v", v.Name(), err)
+		} else {
+			usage.Size = s
+		}
+	}
+	return usage
+}
+
 // Len returns the number of mounts. Used in sorting.
 func (m mounts) Len() int {
 	return len(m)
@@ -183,3 +227,31 @@ func (daemon *Daemon) lazyInitializeVolume(containerID string, m *volume.MountPo
 	}
 	return nil
 }
+
+// VolumeUpdate sets the size of the volume with the given name, in bytes.
+// The driver of the volume must support resizing volumes.
+func (daemon *Daemon) VolumeUpdate(name string, size int64) error {
+	if size <= 0 {
+		return derr.NewBadRequestError(fmt.Errorf("invalid volume size: %d", size))
+	}
+	v, err := daemon.volumes.Get(name)
+	if err != nil {
+		return err
+	}
+	vd, err := volumedrivers.GetDriver(v.DriverName())
+	if err != nil {
+		return err
+	}
+	r, ok := vd.(volume.Resizer)
+	if ok {
+		err = r.Resize(v, size)
+	}
+	if !ok || err == volume.ErrNotSupported {
+		return derr.NewBadRequestError(fmt.Errorf("volume driver %s does not support resizing volumes", vd.Name()))
+	}
+	if err != nil {
+		return err
+	}
+	daemon.LogVolumeEvent(v.Name(), "update", map[string]string{"driver": v.DriverName(), "size": strconv.FormatInt(size, 10)})
+	return nil
+}
@@ -0,0 +1,170 @@
+package daemon
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/docker/docker/errors"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/chrootarchive"
+	"github.com/docker/docker/pkg/ioutils"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/docker/volume"
+	volumedrivers "github.com/docker/docker/volume/drivers"
+	volumestore "github.com/docker/docker/volume/store"
+	"github.com/docker/engine-api/types"
+)
+
+// VolumeExport returns a tar archive of the data of the volume with the
+// given name. It's up to the caller to close the returned stream.
+func (daemon *Daemon) VolumeExport(name string) (io.ReadCloser, error) {
+	v, err := daemon.volumes.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := exportVolume(v)
+	if err != nil {
+		return nil, fmt.Errorf("Error exporting volume %s: %v", name, err)
+	}
+	daemon.LogVolumeEvent(v.Name(), "export", map[string]string{"driver": v.DriverName()})
+	return data, nil
+}
+
+// VolumeImport extracts a tar archive into the volume with the given
+// name. The volume must not be in use by a container.
+func (daemon *Daemon) VolumeImport(name string, data io.Reader) error {
+	v, err := daemon.volumes.Get(name)
+	if err != nil {
+		return err
+	}
+	if refs := daemon.volumes.Refs(v); len(refs) > 0 {
+		return errors.NewRequestConflictError(fmt.Errorf("Unable to import into volume %s, volume still in use: %v", name, refs))
+	}
+
+	if err := importVolume(v, data); err != nil {
+		return fmt.Errorf("Error importing into volume %s: %v", name, err)
+	}
+	daemon.LogVolumeEvent(v.Name(), "import", map[string]string{"driver": v.DriverName()})
+	return nil
+}
+
+// VolumeClone creates a volume with the given name on the driver of the
+// source volume, and copies the data of the source volume into it. The
+// labels of the source volume are used if no labels are given. The source
+// volume must not be in use by a container, which could otherwise change its
+// content while it is copied, and is referenced until the copy is done so
+// that it cannot be removed in the meantime.
+func (daemon *Daemon) VolumeClone(source, name string, opts, labels map[string]string) (*types.Volume, error) {
+	src, err := daemon.volumes.Get(source)
+	if err != nil {
+		return nil, err
+	}
+	ref := "clone-" + stringid.GenerateNonCryptoID()
+	if src, err = daemon.volumes.GetWithRef(src.Name(), src.DriverName(), ref); err != nil {
+		return nil, err
+	}
+	defer daemon.volumes.Dereference(src, ref)
+	for _, r := range daemon.volumes.Refs(src) {
+		if r != ref {
+			return nil, errors.NewRequestConflictError(fmt.Errorf("Unable to clone volume %s, volume still in use by %s", source, r))
+		}
+	}
+	if name == "" {
+		name = stringid.GenerateNonCryptoID()
+	}
+	if labels == nil {
+		if lv, ok := src.(volume.LabeledVolume); ok {
+			labels = lv.Labels()
+		}
+	}
+
+	v, err := daemon.volumes.Create(name, src.DriverName(), opts, labels)
+	if err != nil {
+		if volumestore.IsNameConflict(err) {
+			return nil, fmt.Errorf("A volume named %s already exists. Choose a different volume name.", name)
+		}
+		if volumestore.IsValidationError(err) {
+			return nil, errors.NewBadRequestError(err)
+		}
+		return nil, err
+	}
+
+	if c := volumeCloner(src); c != nil {
+		err = c.Clone(src, v)
+	} else {
+		err = cloneVolume(src, v)
+	}
+	if err != nil {
+		if rmErr := daemon.volumes.Remove(v); rmErr != nil {
+			return nil, fmt.Errorf("Error cloning volume %s: %v, and failed to remove volume %s: %v", source, err, name, rmErr)
+		}
+		return nil, fmt.Errorf("Error cloning volume %s: %v", source, err)
+	}
+
+	daemon.LogVolumeEvent(v.Name(), "create", map[string]string{"driver": v.DriverName(), "source": src.Name()})
+	apiV := volumeToAPIType(v)
+	apiV.Mountpoint = v.Path()
+	return apiV, nil
+}
+
+// volumeCloner returns the driver of the volume if it implements
+// volume.Cloner, and nil otherwise.
+func volumeCloner(v volume.Volume) volume.Cloner {
+	vd, err := volumedrivers.GetDriver(v.DriverName())
+	if err != nil {
+		return nil
+	}
+	c, _ := vd.(volume.Cloner)
+	return c
+}
+
+// exportVolume archives the data of a volume. The volume stays mounted
+// until the archive is closed.
+func exportVolume(v volume.Volume) (io.ReadCloser, error) {
+	id := stringid.GenerateNonCryptoID()
+	path, err := v.Mount(id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := archive.Tar(path, archive.Uncompressed)
+	if err != nil {
+		v.Unmount(id)
+		return nil, err
+	}
+	return ioutils.NewReadCloserWrapper(data, func() error {
+		err := data.Close()
+		if unmountErr := v.Unmount(id); err == nil {
+			err = unmountErr
+		}
+		return err
+	}), nil
+}
+
+// importVolume extracts an archive into a volume.
+func importVolume(v volume.Volume, data io.Reader) error {
+	id := stringid.GenerateNonCryptoID()
+	path, err := v.Mount(id)
+	if err != nil {
+		return err
+	}
+	defer v.Unmount(id)
+	return chrootarchive.Untar(data, path, nil)
+}
+
+// cloneVolume copies the data between volumes whose driver is not a
+// volume.Cloner.
+func cloneVolume(src, dst volume.Volume) error {
+	id := stringid.GenerateNonCryptoID()
+	srcPath, err := src.Mount(id)
+	if err != nil {
+		return err
+	}
+	defer src.Unmount(id)
+	dstPath, err := dst.Mount(id)
+	if err != nil {
+		return err
+	}
+	defer dst.Unmount(id)
+	return chrootarchive.CopyWithTar(srcPath, dstPath)
+}
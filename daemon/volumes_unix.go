@@ -6,7 +6,9 @@ import (
 	"os"
 	"sort"
 	"strconv"
+	"time"
 
+	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/volume"
 )
@@ -48,6 +50,9 @@ func (daemon *Daemon) setupMounts(c *container.Container) ([]container.Mount, er
 					"propagation": m.Propagation,
 				}
 				daemon.LogVolumeEvent(m.Volume.Name(), "mount", attributes)
+				if err := daemon.volumes.SetLastUsed(m.Volume, time.Now().UTC()); err != nil {
+					logrus.Warnf("Unable to record last use of volume %s: %v", m.Volume.Name(), err)
+				}
 			}
 			mounts = append(mounts, mnt)
 		}
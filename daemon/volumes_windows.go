@@ -5,7 +5,9 @@ package daemon
 import (
 	"fmt"
 	"sort"
+	"time"
 
+	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/volume"
 )
@@ -30,6 +32,11 @@ func (daemon *Daemon) setupMounts(c *container.Container) ([]container.Mount, er
 		if s == "" && mount.Volume != nil {
 			s = mount.Volume.Path()
 		}
+		if mount.Volume != nil {
+			if err := daemon.volumes.SetLastUsed(mount.Volume, time.Now().UTC()); err != nil {
+				logrus.Warnf("Unable to record last use of volume %s: %v", mount.Volume.Name(), err)
+			}
+		}
 		if s == "" {
 			return nil, fmt.Errorf("No source for mount name '%s' driver %q destination '%s'", mount.Name, mount.Driver, mount.Destination)
 		}
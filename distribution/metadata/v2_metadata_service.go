@@ -17,6 +17,19 @@ type V2MetadataService struct {
 type V2Metadata struct {
 	Digest           digest.Digest
 	SourceRepository string
+	// Compression is the name of the compression algorithm of the blob
+	// referenced by Digest. An empty value stands for gzip, which was the
+	// only algorithm used before this field was introduced.
+	Compression string `json:",omitempty"`
+}
+
+// CompressionName returns the name of the compression algorithm of the blob
+// described by the metadata.
+func (meta V2Metadata) CompressionName() string {
+	if meta.Compression == "" {
+		return "gzip"
+	}
+	return meta.Compression
 }
 
 // maxMetadata is the number of metadata entries to keep per layer DiffID.
@@ -48,6 +48,13 @@ func TestV2MetadataService(t *testing.T) {
 				{Digest: digest.Digest("sha256:9e3447ca24cb96d86ebd5960cb34d1299b07e0a0e03801d90b9969a2c187dd6e")},
 			},
 		},
+		{
+			diffID: layer.DiffID("sha256:d2a31b2fcd6b4d4fd2c3b4ba9f1fdb1d7d0e1e9a3f0d4b1f5fcbbf3c9f2da56c"),
+			metadata: []V2Metadata{
+				{Digest: digest.Digest("sha256:f0cd5ca10b07f35512fc2f1cbf9a6cefbdb5cba70ac6b0c9e5988f4497f71937")},
+				{Digest: digest.Digest("sha256:0a9d8e9c1c0b8f3e1bbf6b6e0a8b7d8b9f8b3a6f5e6e1c8d7d0d9b8a7c6b5a4f"), Compression: "zstd"},
+			},
+		},
 		{
 			diffID:   layer.DiffID("sha256:03f4658f8b782e12230c1783426bd3bacce651ce582a4ffb6fbbfa2079428ecb"),
 			metadata: tooManyBlobSums,
@@ -7,6 +7,7 @@ import (
 	"github.com/docker/distribution/digest"
 	"github.com/docker/docker/api"
 	"github.com/docker/docker/distribution/metadata"
+	"github.com/docker/docker/distribution/signature"
 	"github.com/docker/docker/distribution/xfer"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/pkg/progress"
@@ -40,6 +41,11 @@ type ImagePullConfig struct {
 	ReferenceStore reference.Store
 	// DownloadManager manages concurrent pulls.
 	DownloadManager *xfer.LayerDownloadManager
+	// SignaturePolicy is the signature policy pulled images are checked
+	// against. If it is nil, images don't need to be signed.
+	SignaturePolicy *signature.Policy
+	// SignatureStore records the signatures of pulled images.
+	SignatureStore *signature.Store
 }
 
 // Puller is an interface that abstracts pulling for different API versions.
@@ -122,6 +128,14 @@ func Pull(ctx context.Context, ref reference.Named, imagePullConfig *ImagePullCo
 			continue
 		}
 
+		if endpoint.Version == registry.APIVersion1 && imagePullConfig.SignaturePolicy != nil && imagePullConfig.SignaturePolicy.ForRegistry(repoInfo.Hostname()) != nil {
+			logrus.Debugf("Skipping v1 endpoint %s because signatures are required for %s", endpoint.URL, repoInfo.Hostname())
+			if lastErr == nil {
+				lastErr = fmt.Errorf("signature policy: %s requires signed images, which can't be pulled from a v1 registry", repoInfo.Hostname())
+			}
+			continue
+		}
+
 		if endpoint.URL.Scheme != "https" {
 			if _, confirmedTLS := confirmedTLSRegistries[endpoint.URL.Host]; confirmedTLS {
 				logrus.Debugf("Skipping non-TLS endpoint %s for host/port that appears to use TLS", endpoint.URL)
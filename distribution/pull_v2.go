@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"net/url"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 
@@ -21,6 +22,7 @@ import (
 	"github.com/docker/distribution/registry/client/auth"
 	"github.com/docker/distribution/registry/client/transport"
 	"github.com/docker/docker/distribution/metadata"
+	"github.com/docker/docker/distribution/signature"
 	"github.com/docker/docker/distribution/xfer"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/image/v1"
@@ -162,26 +164,56 @@ func (ld *v2LayerDescriptor) Download(ctx context.Context, progressOutput progre
 	)
 
 	if ld.tmpFile == nil {
-		ld.tmpFile, err = createDownloadFile()
+		// This may pick up a partial download left behind by an
+		// earlier pull, or by a previous run of the daemon.
+		ld.tmpFile, err = createDownloadFile(ld.digest)
 		if err != nil {
 			return nil, 0, xfer.DoNotRetry{Err: err}
 		}
-	} else {
-		offset, err = ld.tmpFile.Seek(0, os.SEEK_END)
-		if err != nil {
-			logrus.Debugf("error seeking to end of download file: %v", err)
-			offset = 0
+	}
 
-			ld.tmpFile.Close()
-			if err := os.Remove(ld.tmpFile.Name()); err != nil {
-				logrus.Errorf("Failed to remove temp file: %s", ld.tmpFile.Name())
-			}
-			ld.tmpFile, err = createDownloadFile()
-			if err != nil {
-				return nil, 0, xfer.DoNotRetry{Err: err}
+	offset, err = ld.tmpFile.Seek(0, os.SEEK_END)
+	if err != nil {
+		logrus.Debugf("error seeking to end of download file: %v", err)
+		offset = 0
+
+		ld.tmpFile.Close()
+		if err := os.Remove(ld.tmpFile.Name()); err != nil {
+			logrus.Errorf("Failed to remove temp file: %s", ld.tmpFile.Name())
+		}
+		ld.verifier = nil
+		ld.tmpFile, err = createDownloadFile(ld.digest)
+		if err != nil {
+			return nil, 0, xfer.DoNotRetry{Err: err}
+		}
+	} else if offset != 0 {
+		logrus.Debugf("attempting to resume download of %q from %d bytes", ld.digest, offset)
+
+		if ld.verifier == nil {
+			// The partial download was not written during this
+			// session, so the data we already have needs to be
+			// hashed before the rest of the blob is appended.
+			if err := ld.resumeVerifier(offset); err != nil {
+				logrus.Debugf("error hashing partial download of %q: %v", ld.digest, err)
+				offset = 0
+				if err := ld.truncateDownloadFile(); err != nil {
+					return nil, 0, xfer.DoNotRetry{Err: err}
+				}
 			}
-		} else if offset != 0 {
-			logrus.Debugf("attempting to resume download of %q from %d bytes", ld.digest, offset)
+		}
+	}
+
+	// A previous attempt may already have fetched the whole blob, in which
+	// case there is nothing left to download. A range request past the end
+	// of the blob would be rejected by the registry.
+	if offset != 0 && offset == ld.src.Size {
+		if ld.verifier.Verified() {
+			logrus.Debugf("partial download of %q is already complete", ld.digest)
+			return ld.completeDownload(progressOutput, offset)
+		}
+		offset = 0
+		if err := ld.truncateDownloadFile(); err != nil {
+			return nil, 0, xfer.DoNotRetry{Err: err}
 		}
 	}
 
@@ -265,14 +297,28 @@ func (ld *v2LayerDescriptor) Download(ctx context.Context, progressOutput progre
 
 			return nil, 0, err
 		}
+
+		// Don't keep the corrupt data around for a later pull to
+		// resume from.
+		if err := ld.truncateDownloadFile(); err != nil {
+			logrus.Errorf("Failed to truncate download file: %s", tmpFile.Name())
+		}
 		return nil, 0, xfer.DoNotRetry{Err: err}
 	}
 
+	return ld.completeDownload(progressOutput, size)
+}
+
+// completeDownload hands off the verified download file to the download
+// manager, which removes it once the layer has been registered.
+func (ld *v2LayerDescriptor) completeDownload(progressOutput progress.Output, size int64) (io.ReadCloser, int64, error) {
+	tmpFile := ld.tmpFile
+
 	progress.Update(progressOutput, ld.ID(), "Download complete")
 
 	logrus.Debugf("Downloaded %s to tempfile %s", ld.ID(), tmpFile.Name())
 
-	_, err = tmpFile.Seek(0, os.SEEK_SET)
+	_, err := tmpFile.Seek(0, os.SEEK_SET)
 	if err != nil {
 		tmpFile.Close()
 		if err := os.Remove(tmpFile.Name()); err != nil {
@@ -300,6 +346,12 @@ func (ld *v2LayerDescriptor) Download(ctx context.Context, progressOutput progre
 func (ld *v2LayerDescriptor) Close() {
 	if ld.tmpFile != nil {
 		ld.tmpFile.Close()
+		// Keep an incomplete download of a known digest around, so
+		// that a later pull can resume it instead of starting over.
+		if fi, err := os.Stat(ld.tmpFile.Name()); err == nil && fi.Size() > 0 && ld.tmpFile.Name() == partialDownloadPath(ld.digest) {
+			logrus.Debugf("keeping partial download of %q (%d bytes)", ld.digest, fi.Size())
+			return
+		}
 		if err := os.RemoveAll(ld.tmpFile.Name()); err != nil {
 			logrus.Errorf("Failed to remove temp file: %s", ld.tmpFile.Name())
 		}
@@ -323,9 +375,27 @@ func (ld *v2LayerDescriptor) truncateDownloadFile() error {
 	return nil
 }
 
+// resumeVerifier creates a new verifier for the blob and feeds it the
+// first offset bytes of the download file, leaving the file positioned
+// at offset.
+func (ld *v2LayerDescriptor) resumeVerifier(offset int64) error {
+	verifier, err := digest.NewDigestVerifier(ld.digest)
+	if err != nil {
+		return err
+	}
+	if _, err := ld.tmpFile.Seek(0, os.SEEK_SET); err != nil {
+		return err
+	}
+	if _, err := io.CopyN(verifier, ld.tmpFile, offset); err != nil {
+		return err
+	}
+	ld.verifier = verifier
+	return nil
+}
+
 func (ld *v2LayerDescriptor) Registered(diffID layer.DiffID) {
 	// Cache mapping from this layer's DiffID to the blobsum
-	ld.V2MetadataService.Add(diffID, metadata.V2Metadata{Digest: ld.digest, SourceRepository: ld.repoInfo.FullName()})
+	ld.V2MetadataService.Add(diffID, metadata.V2Metadata{Digest: ld.digest, SourceRepository: ld.repoInfo.FullName(), Compression: layerCompressionName(ld.src.MediaType)})
 }
 
 func (p *v2Puller) pullV2Tag(ctx context.Context, ref reference.Named) (tagUpdated bool, err error) {
@@ -371,6 +441,12 @@ func (p *v2Puller) pullV2Tag(ctx context.Context, ref reference.Named) (tagUpdat
 	// the other side speaks the v2 protocol.
 	p.confirmedV2 = true
 
+	// Check the signatures before anything is downloaded.
+	signatures, err := p.verifySignatures(ctx, manifest)
+	if err != nil {
+		return false, err
+	}
+
 	logrus.Debugf("Pulling ref from V2 registry: %s", ref.String())
 	progress.Message(p.config.ProgressOutput, tagOrDigest, "Pulling from "+p.repo.Named().Name())
 
@@ -399,6 +475,12 @@ func (p *v2Puller) pullV2Tag(ctx context.Context, ref reference.Named) (tagUpdat
 		return false, errors.New("unsupported manifest format")
 	}
 
+	if signatures != nil {
+		if err := p.config.SignatureStore.Add(imageID, *signatures); err != nil {
+			return false, err
+		}
+	}
+
 	progress.Message(p.config.ProgressOutput, "", "Digest: "+manifestDigest.String())
 
 	oldTagImageID, err := p.config.ReferenceStore.Get(ref)
@@ -425,6 +507,57 @@ func (p *v2Puller) pullV2Tag(ctx context.Context, ref reference.Named) (tagUpdat
 	return true, nil
 }
 
+// verifySignatures checks the signatures of the manifest against the
+// signature policy of the registry. It returns the signatures to record for
+// the image, or nil if the registry does not require signed images.
+func (p *v2Puller) verifySignatures(ctx context.Context, manifest distribution.Manifest) (*signature.Record, error) {
+	if p.config.SignaturePolicy == nil {
+		return nil, nil
+	}
+	rp := p.config.SignaturePolicy.ForRegistry(p.repoInfo.Hostname())
+	if rp == nil {
+		return nil, nil
+	}
+
+	var canonical []byte
+	if m, ok := manifest.(*schema1.SignedManifest); ok {
+		canonical = m.Canonical
+	} else {
+		_, payload, err := manifest.Payload()
+		if err != nil {
+			return nil, err
+		}
+		canonical = payload
+	}
+
+	record := &signature.Record{
+		Repository: p.repoInfo.FullName(),
+		Digest:     digest.FromBytes(canonical),
+	}
+
+	// Schema1 manifests carry their own signatures.
+	if m, ok := manifest.(*schema1.SignedManifest); ok {
+		keys, err := schema1.Verify(m)
+		if err != nil {
+			logrus.Debugf("error verifying manifest signatures of %s: %v", p.repoInfo.FullName(), err)
+		}
+		for _, k := range keys {
+			record.ManifestKeyIDs = append(record.ManifestKeyIDs, k.KeyID())
+		}
+	}
+
+	signatures, err := rp.FetchSignatures(ctx, p.repoInfo, record.Digest)
+	if err != nil {
+		return nil, err
+	}
+	record.Signatures = signatures
+
+	if err := rp.Verify(*record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
 func (p *v2Puller) pullSchema1(ctx context.Context, ref reference.Named, unverifiedManifest *schema1.SignedManifest) (imageID image.ID, manifestDigest digest.Digest, err error) {
 	var verifiedManifest *schema1.Manifest
 	verifiedManifest, err = verifySchema1Manifest(unverifiedManifest, ref)
@@ -855,6 +988,30 @@ func fixManifestLayers(m *schema1.Manifest) error {
 	return nil
 }
 
-func createDownloadFile() (*os.File, error) {
-	return ioutil.TempFile("", "GetImageBlob")
+// partialDownloadsDir is the directory, inside the daemon's temporary
+// directory, where layer downloads are stored while they are in progress.
+// Downloads which are interrupted are left in place, so that they can be
+// resumed by a later pull, even after the daemon has been restarted.
+const partialDownloadsDir = "partial-downloads"
+
+// partialDownloadPath returns the path of the download file for the blob
+// with the specified digest.
+func partialDownloadPath(dgst digest.Digest) string {
+	return filepath.Join(os.TempDir(), partialDownloadsDir, dgst.Algorithm().String()+"-"+dgst.Hex())
+}
+
+// createDownloadFile opens the download file for the blob with the
+// specified digest, creating it if it does not exist yet. Any data already
+// in the file is the beginning of the blob, from an earlier attempt to
+// download it. A digest that can't be used to name a file falls back to an
+// anonymous temporary file.
+func createDownloadFile(dgst digest.Digest) (*os.File, error) {
+	if err := dgst.Validate(); err != nil {
+		return ioutil.TempFile("", "GetImageBlob")
+	}
+	p := partialDownloadPath(dgst)
+	if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(p, os.O_RDWR|os.O_CREATE, 0600)
 }
@@ -1,16 +1,22 @@
 package distribution
 
 import (
+	"bytes"
 	"encoding/json"
 	"io/ioutil"
+	"os"
 	"reflect"
 	"runtime"
 	"strings"
 	"testing"
 
+	"github.com/docker/distribution"
+	dcontext "github.com/docker/distribution/context"
 	"github.com/docker/distribution/digest"
 	"github.com/docker/distribution/manifest/schema1"
+	"github.com/docker/docker/pkg/progress"
 	"github.com/docker/docker/reference"
+	"golang.org/x/net/context"
 )
 
 // TestFixManifestLayers checks that fixManifestLayers removes a duplicate
@@ -181,3 +187,170 @@ func TestValidateManifest(t *testing.T) {
 		t.Fatal("expected validateManifest to fail with digest error")
 	}
 }
+
+type mockBlob struct {
+	r     *bytes.Reader
+	reads int64
+}
+
+func (b *mockBlob) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	b.reads += int64(n)
+	return n, err
+}
+
+func (b *mockBlob) Seek(offset int64, whence int) (int64, error) {
+	return b.r.Seek(offset, whence)
+}
+
+func (b *mockBlob) Close() error {
+	return nil
+}
+
+type mockBlobStore struct {
+	distribution.BlobStore
+	blob *mockBlob
+}
+
+func (bs *mockBlobStore) Open(ctx dcontext.Context, dgst digest.Digest) (distribution.ReadSeekCloser, error) {
+	return bs.blob, nil
+}
+
+type mockRepository struct {
+	distribution.Repository
+	blobs *mockBlobStore
+}
+
+func (r *mockRepository) Blobs(ctx dcontext.Context) distribution.BlobStore {
+	return r.blobs
+}
+
+type discardOutput struct{}
+
+func (discardOutput) WriteProgress(progress.Progress) error {
+	return nil
+}
+
+// TestResumePartialDownload checks that a download picks up the data left
+// behind by an earlier, interrupted download of the same blob, that only the
+// missing part of the blob is fetched, and that the download file is removed
+// once the download manager is done with it.
+func TestResumePartialDownload(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "partial-download-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	defer os.Setenv("TMPDIR", os.Getenv("TMPDIR"))
+	os.Setenv("TMPDIR", tmpDir)
+
+	content := bytes.Repeat([]byte("layer data "), 1000)
+	dgst := digest.FromBytes(content)
+	partial := 4000
+
+	// Simulate an interrupted download
+	f, err := createDownloadFile(dgst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write(content[:partial]); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	blob := &mockBlob{r: bytes.NewReader(content)}
+	ld := &v2LayerDescriptor{
+		digest: dgst,
+		repo:   &mockRepository{blobs: &mockBlobStore{blob: blob}},
+		src:    distribution.Descriptor{Digest: dgst, Size: int64(len(content))},
+	}
+	defer ld.Close()
+
+	rc, size, err := ld.Download(context.Background(), discardOutput{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size != int64(len(content)) {
+		t.Fatalf("expected size %d, got %d", len(content), size)
+	}
+	if expected := int64(len(content) - partial); blob.reads != expected {
+		t.Fatalf("expected %d bytes to be fetched, got %d", expected, blob.reads)
+	}
+
+	downloaded, err := ioutil.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(downloaded, content) {
+		t.Fatal("downloaded content does not match the blob")
+	}
+
+	rc.Close()
+	if _, err := os.Stat(partialDownloadPath(dgst)); !os.IsNotExist(err) {
+		t.Fatalf("expected download file to be removed, got %v", err)
+	}
+}
+
+// TestKeepPartialDownload checks that an incomplete download is kept when
+// the download manager gives up on it, and that corrupt partial data is
+// discarded instead of failing the download.
+func TestKeepPartialDownload(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "partial-download-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	defer os.Setenv("TMPDIR", os.Getenv("TMPDIR"))
+	os.Setenv("TMPDIR", tmpDir)
+
+	content := bytes.Repeat([]byte("layer data "), 1000)
+	dgst := digest.FromBytes(content)
+
+	ld := &v2LayerDescriptor{digest: dgst}
+	ld.tmpFile, err = createDownloadFile(dgst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ld.tmpFile.Write(content[:100]); err != nil {
+		t.Fatal(err)
+	}
+	ld.Close()
+
+	fi, err := os.Stat(partialDownloadPath(dgst))
+	if err != nil {
+		t.Fatalf("expected partial download to be kept: %v", err)
+	}
+	if fi.Size() != 100 {
+		t.Fatalf("expected partial download of 100 bytes, got %d", fi.Size())
+	}
+
+	// Overwrite the partial download with data that does not belong to
+	// the blob. The whole blob must be fetched again.
+	if err := ioutil.WriteFile(partialDownloadPath(dgst), bytes.Repeat([]byte("x"), len(content)), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	blob := &mockBlob{r: bytes.NewReader(content)}
+	ld = &v2LayerDescriptor{
+		digest: dgst,
+		repo:   &mockRepository{blobs: &mockBlobStore{blob: blob}},
+		src:    distribution.Descriptor{Digest: dgst, Size: int64(len(content))},
+	}
+	defer ld.Close()
+
+	rc, _, err := ld.Download(context.Background(), discardOutput{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	if blob.reads != int64(len(content)) {
+		t.Fatalf("expected the whole blob to be fetched, got %d bytes", blob.reads)
+	}
+	downloaded, err := ioutil.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(downloaded, content) {
+		t.Fatal("downloaded content does not match the blob")
+	}
+}
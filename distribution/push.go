@@ -7,10 +7,12 @@ import (
 	"io"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/docker/distribution/manifest/schema2"
 	"github.com/docker/docker/distribution/metadata"
 	"github.com/docker/docker/distribution/xfer"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/progress"
 	"github.com/docker/docker/reference"
 	"github.com/docker/docker/registry"
@@ -48,6 +50,14 @@ type ImagePushConfig struct {
 	TrustKey libtrust.PrivateKey
 	// UploadManager dispatches uploads.
 	UploadManager *xfer.LayerUploadManager
+	// LayerCompression is the compression algorithm applied to layers
+	// uploaded to a v2 registry. Only gzip and zstd are supported, the
+	// zero value selects gzip.
+	LayerCompression archive.Compression
+	// LayerCompressionLevel is the compression level applied to layers
+	// uploaded to a v2 registry. 0 selects the default level of the
+	// algorithm.
+	LayerCompressionLevel int
 }
 
 // Pusher is an interface that abstracts pushing for different API versions.
@@ -61,6 +71,9 @@ type Pusher interface {
 
 const compressionBufSize = 32768
 
+// MediaTypeLayerZstd is the media type used for layers compressed with zstd.
+const MediaTypeLayerZstd = "application/vnd.docker.image.rootfs.diff.tar.zstd"
+
 // NewPusher creates a new Pusher interface that will push to either a v1 or v2
 // registry. The endpoint argument contains a Version field that determines
 // whether a v1 or v2 pusher will be created. The other parameters are passed
@@ -191,13 +204,17 @@ func Push(ctx context.Context, ref reference.Named, imagePushConfig *ImagePushCo
 // is finished. This allows the caller to make sure the goroutine finishes
 // before it releases any resources connected with the reader that was
 // passed in.
-func compress(in io.Reader) (io.ReadCloser, chan struct{}) {
+func compress(in io.Reader, compression archive.Compression, level int) (io.ReadCloser, chan struct{}, error) {
 	compressionDone := make(chan struct{})
 
 	pipeReader, pipeWriter := io.Pipe()
 	// Use a bufio.Writer to avoid excessive chunking in HTTP request.
 	bufWriter := bufio.NewWriterSize(pipeWriter, compressionBufSize)
-	compressor := gzip.NewWriter(bufWriter)
+	compressor, err := archive.CompressStreamLevel(bufWriter, compression, level)
+	if err != nil {
+		pipeWriter.Close()
+		return nil, nil, err
+	}
 
 	go func() {
 		_, err := io.Copy(compressor, in)
@@ -215,5 +232,41 @@ func compress(in io.Reader) (io.ReadCloser, chan struct{}) {
 		close(compressionDone)
 	}()
 
-	return pipeReader, compressionDone
+	return pipeReader, compressionDone, nil
+}
+
+// ValidateLayerCompression returns an error if layers cannot be pushed with
+// the specified compression algorithm and level.
+func ValidateLayerCompression(compression archive.Compression, level int) error {
+	switch compression {
+	case archive.Gzip:
+		if level < gzip.HuffmanOnly || level > gzip.BestCompression {
+			return fmt.Errorf("invalid gzip compression level %d: must be between %d and %d", level, gzip.HuffmanOnly, gzip.BestCompression)
+		}
+	case archive.Zstd:
+		if level < 0 || level > 19 {
+			return fmt.Errorf("invalid zstd compression level %d: must be between 1 and 19, or 0 for the default level", level)
+		}
+	default:
+		return fmt.Errorf("unsupported layer compression %q: only gzip and zstd are supported", compression.Name())
+	}
+	return nil
+}
+
+// layerMediaType returns the media type of a layer blob compressed with the
+// specified algorithm.
+func layerMediaType(compressionName string) string {
+	if compressionName == "zstd" {
+		return MediaTypeLayerZstd
+	}
+	return schema2.MediaTypeLayer
+}
+
+// layerCompressionName returns the name of the compression algorithm of a
+// layer blob with the specified media type.
+func layerCompressionName(mediaType string) string {
+	if mediaType == MediaTypeLayerZstd {
+		return "zstd"
+	}
+	return ""
 }
@@ -17,6 +17,7 @@ import (
 	"github.com/docker/docker/distribution/xfer"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/progress"
 	"github.com/docker/docker/pkg/stringid"
@@ -134,12 +135,19 @@ func (p *v2Pusher) pushV2Tag(ctx context.Context, ref reference.NamedTagged, ima
 
 	var descriptors []xfer.UploadDescriptor
 
+	compression := p.config.LayerCompression
+	if compression == archive.Uncompressed {
+		compression = archive.Gzip
+	}
+
 	descriptorTemplate := v2PushDescriptor{
 		v2MetadataService: p.v2MetadataService,
 		repoInfo:          p.repoInfo,
 		ref:               p.ref,
 		repo:              p.repo,
 		pushState:         &p.pushState,
+		compression:       compression,
+		compressionLevel:  p.config.LayerCompressionLevel,
 	}
 
 	// Loop bounds condition is to avoid pushing the base layer on Windows.
@@ -232,6 +240,8 @@ type v2PushDescriptor struct {
 	repo              distribution.Repository
 	pushState         *pushState
 	remoteDescriptor  distribution.Descriptor
+	compression       archive.Compression
+	compressionLevel  int
 }
 
 func (pd *v2PushDescriptor) Key() string {
@@ -269,7 +279,7 @@ func (pd *v2PushDescriptor) Upload(ctx context.Context, progressOutput progress.
 	// Do we have any metadata associated with this layer's DiffID?
 	v2Metadata, err := pd.v2MetadataService.GetMetadata(diffID)
 	if err == nil {
-		descriptor, exists, err := layerAlreadyExists(ctx, v2Metadata, pd.repoInfo, pd.repo, pd.pushState)
+		descriptor, exists, err := layerAlreadyExists(ctx, v2Metadata, pd.repoInfo, pd.repo, pd.pushState, pd.compression.Name())
 		if err != nil {
 			progress.Update(progressOutput, pd.ID(), "Image push failed")
 			return distribution.Descriptor{}, retryOnError(err)
@@ -334,7 +344,7 @@ func (pd *v2PushDescriptor) Upload(ctx context.Context, progressOutput progress.
 		case distribution.ErrBlobMounted:
 			progress.Updatef(progressOutput, pd.ID(), "Mounted from %s", err.From.Name())
 
-			err.Descriptor.MediaType = schema2.MediaTypeLayer
+			err.Descriptor.MediaType = layerMediaType(mountFrom.CompressionName())
 
 			pd.pushState.Lock()
 			pd.pushState.confirmedV2 = true
@@ -342,7 +352,7 @@ func (pd *v2PushDescriptor) Upload(ctx context.Context, progressOutput progress.
 			pd.pushState.Unlock()
 
 			// Cache mapping from this layer's DiffID to the blobsum
-			if err := pd.v2MetadataService.Add(diffID, metadata.V2Metadata{Digest: mountFrom.Digest, SourceRepository: pd.repoInfo.FullName()}); err != nil {
+			if err := pd.v2MetadataService.Add(diffID, metadata.V2Metadata{Digest: mountFrom.Digest, SourceRepository: pd.repoInfo.FullName(), Compression: mountFrom.Compression}); err != nil {
 				return distribution.Descriptor{}, xfer.DoNotRetry{Err: err}
 			}
 			return err.Descriptor, nil
@@ -374,7 +384,11 @@ func (pd *v2PushDescriptor) Upload(ctx context.Context, progressOutput progress.
 	size, _ := pd.layer.DiffSize()
 
 	reader := progress.NewProgressReader(ioutils.NewCancelReadCloser(ctx, arch), progressOutput, size, pd.ID(), "Pushing")
-	compressedReader, compressionDone := compress(reader)
+	compressedReader, compressionDone, err := compress(reader, pd.compression, pd.compressionLevel)
+	if err != nil {
+		reader.Close()
+		return distribution.Descriptor{}, xfer.DoNotRetry{Err: err}
+	}
 	defer func() {
 		reader.Close()
 		<-compressionDone
@@ -397,8 +411,10 @@ func (pd *v2PushDescriptor) Upload(ctx context.Context, progressOutput progress.
 	logrus.Debugf("uploaded layer %s (%s), %d bytes", diffID, pushDigest, nn)
 	progress.Update(progressOutput, pd.ID(), "Pushed")
 
+	mediaType := layerMediaType(pd.compression.Name())
+
 	// Cache mapping from this layer's DiffID to the blobsum
-	if err := pd.v2MetadataService.Add(diffID, metadata.V2Metadata{Digest: pushDigest, SourceRepository: pd.repoInfo.FullName()}); err != nil {
+	if err := pd.v2MetadataService.Add(diffID, metadata.V2Metadata{Digest: pushDigest, SourceRepository: pd.repoInfo.FullName(), Compression: layerCompressionName(mediaType)}); err != nil {
 		return distribution.Descriptor{}, xfer.DoNotRetry{Err: err}
 	}
 
@@ -410,7 +426,7 @@ func (pd *v2PushDescriptor) Upload(ctx context.Context, progressOutput progress.
 
 	descriptor := distribution.Descriptor{
 		Digest:    pushDigest,
-		MediaType: schema2.MediaTypeLayer,
+		MediaType: mediaType,
 		Size:      nn,
 	}
 	pd.pushState.remoteLayers[diffID] = descriptor
@@ -430,9 +446,11 @@ func (pd *v2PushDescriptor) Descriptor() distribution.Descriptor {
 
 // layerAlreadyExists checks if the registry already know about any of the
 // metadata passed in the "metadata" slice. If it finds one that the registry
-// knows about, it returns the known digest and "true".
-func layerAlreadyExists(ctx context.Context, metadata []metadata.V2Metadata, repoInfo reference.Named, repo distribution.Repository, pushState *pushState) (distribution.Descriptor, bool, error) {
-	for _, meta := range metadata {
+// knows about, it returns the known digest and "true". Blobs compressed with
+// the preferred compression algorithm are checked first, but a blob of the
+// layer compressed with any other algorithm is reused as well.
+func layerAlreadyExists(ctx context.Context, metadata []metadata.V2Metadata, repoInfo reference.Named, repo distribution.Repository, pushState *pushState, preferredCompression string) (distribution.Descriptor, bool, error) {
+	for _, meta := range sortByCompression(metadata, preferredCompression) {
 		// Only check blobsums that are known to this repository or have an unknown source
 		if meta.SourceRepository != "" && meta.SourceRepository != repoInfo.FullName() {
 			continue
@@ -440,7 +458,7 @@ func layerAlreadyExists(ctx context.Context, metadata []metadata.V2Metadata, rep
 		descriptor, err := repo.Blobs(ctx).Stat(ctx, meta.Digest)
 		switch err {
 		case nil:
-			descriptor.MediaType = schema2.MediaTypeLayer
+			descriptor.MediaType = layerMediaType(meta.CompressionName())
 			return descriptor, true, nil
 		case distribution.ErrBlobUnknown:
 			// nop
@@ -450,3 +468,21 @@ func layerAlreadyExists(ctx context.Context, metadata []metadata.V2Metadata, rep
 	}
 	return distribution.Descriptor{}, false, nil
 }
+
+// sortByCompression returns the metadata entries of blobs compressed with the
+// preferred compression algorithm, followed by all other entries. The
+// relative order of the entries is preserved.
+func sortByCompression(v2Metadata []metadata.V2Metadata, preferredCompression string) []metadata.V2Metadata {
+	sorted := make([]metadata.V2Metadata, 0, len(v2Metadata))
+	for _, meta := range v2Metadata {
+		if meta.CompressionName() == preferredCompression {
+			sorted = append(sorted, meta)
+		}
+	}
+	for _, meta := range v2Metadata {
+		if meta.CompressionName() != preferredCompression {
+			sorted = append(sorted, meta)
+		}
+	}
+	return sorted
+}
@@ -0,0 +1,79 @@
+package distribution
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/distribution/digest"
+	"github.com/docker/distribution/manifest/schema2"
+	"github.com/docker/docker/distribution/metadata"
+	"github.com/docker/docker/pkg/archive"
+)
+
+func TestSortByCompression(t *testing.T) {
+	gzipMeta := metadata.V2Metadata{Digest: digest.Digest("sha256:f0cd5ca10b07f35512fc2f1cbf9a6cefbdb5cba70ac6b0c9e5988f4497f71937")}
+	zstdMeta := metadata.V2Metadata{Digest: digest.Digest("sha256:9e3447ca24cb96d86ebd5960cb34d1299b07e0a0e03801d90b9969a2c187dd6e"), Compression: "zstd"}
+	otherGzipMeta := metadata.V2Metadata{Digest: digest.Digest("sha256:86e0e091d0da6bde2456dbb48306f3956bbeb2eae1b5b9a43045843f69fe4aaa"), Compression: "gzip"}
+
+	v2Metadata := []metadata.V2Metadata{gzipMeta, zstdMeta, otherGzipMeta}
+
+	sorted := sortByCompression(v2Metadata, "zstd")
+	expected := []metadata.V2Metadata{zstdMeta, gzipMeta, otherGzipMeta}
+	if !reflect.DeepEqual(sorted, expected) {
+		t.Fatalf("unexpected order: got %v, expected %v", sorted, expected)
+	}
+
+	sorted = sortByCompression(v2Metadata, "gzip")
+	expected = []metadata.V2Metadata{gzipMeta, otherGzipMeta, zstdMeta}
+	if !reflect.DeepEqual(sorted, expected) {
+		t.Fatalf("unexpected order: got %v, expected %v", sorted, expected)
+	}
+}
+
+func TestLayerMediaType(t *testing.T) {
+	if mediaType := layerMediaType("gzip"); mediaType != schema2.MediaTypeLayer {
+		t.Fatalf("unexpected media type for gzip layers: %s", mediaType)
+	}
+	if mediaType := layerMediaType("zstd"); mediaType != MediaTypeLayerZstd {
+		t.Fatalf("unexpected media type for zstd layers: %s", mediaType)
+	}
+	if name := layerCompressionName(MediaTypeLayerZstd); name != "zstd" {
+		t.Fatalf("unexpected compression for zstd layers: %s", name)
+	}
+	if name := layerCompressionName(schema2.MediaTypeLayer); name != "" {
+		t.Fatalf("unexpected compression for gzip layers: %s", name)
+	}
+}
+
+func TestValidateLayerCompression(t *testing.T) {
+	valid := []struct {
+		compression archive.Compression
+		level       int
+	}{
+		{archive.Gzip, 0},
+		{archive.Gzip, 9},
+		{archive.Zstd, 0},
+		{archive.Zstd, 19},
+	}
+	for _, v := range valid {
+		if err := ValidateLayerCompression(v.compression, v.level); err != nil {
+			t.Fatalf("unexpected error for %s level %d: %v", v.compression.Name(), v.level, err)
+		}
+	}
+
+	invalid := []struct {
+		compression archive.Compression
+		level       int
+	}{
+		{archive.Gzip, 10},
+		{archive.Zstd, 20},
+		{archive.Zstd, -1},
+		{archive.Xz, 0},
+		{archive.Uncompressed, 0},
+	}
+	for _, v := range invalid {
+		if err := ValidateLayerCompression(v.compression, v.level); err == nil {
+			t.Fatalf("expected an error for %s level %d", v.compression.Name(), v.level)
+		}
+	}
+}
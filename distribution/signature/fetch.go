@@ -0,0 +1,100 @@
+package signature
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/docker/distribution/digest"
+	"github.com/docker/docker/reference"
+	"golang.org/x/net/context"
+	"golang.org/x/net/context/ctxhttp"
+)
+
+const (
+	// maxSignatures is the maximum number of signatures fetched for a
+	// manifest.
+	maxSignatures = 32
+	// maxSignatureSize is the maximum size of a signature.
+	maxSignatureSize = 64 * 1024
+)
+
+// FetchSignatures fetches the signatures of a manifest from the signature
+// store of the policy. The signatures of a manifest are stored at
+// <signature-store>/<repository>@<algorithm>=<hex>/signature-<n>, where
+// repository is the name of the repository without the registry hostname,
+// and n counts up from 1. The first missing signature ends the list.
+func (rp *RegistryPolicy) FetchSignatures(ctx context.Context, repository reference.Named, dgst digest.Digest) ([][]byte, error) {
+	if rp.SignatureStore == "" {
+		return nil, nil
+	}
+	base, err := url.Parse(rp.SignatureStore)
+	if err != nil {
+		return nil, err
+	}
+	dir := repository.RemoteName() + "@" + dgst.Algorithm().String() + "=" + dgst.Hex()
+
+	var signatures [][]byte
+	for i := 1; i <= maxSignatures; i++ {
+		name := fmt.Sprintf("signature-%d", i)
+		var sig []byte
+		if base.Scheme == "file" {
+			sig, err = readSignatureFile(filepath.Join(base.Path, filepath.FromSlash(dir), name))
+		} else {
+			u := *base
+			u.Path = path.Join(u.Path, dir, name)
+			sig, err = readSignatureURL(ctx, u.String())
+		}
+		if err != nil {
+			return nil, err
+		}
+		if sig == nil {
+			break
+		}
+		signatures = append(signatures, sig)
+	}
+	return signatures, nil
+}
+
+func readSignatureFile(p string) ([]byte, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	return readSignature(f, p)
+}
+
+func readSignatureURL(ctx context.Context, u string) ([]byte, error) {
+	resp, err := ctxhttp.Get(ctx, http.DefaultClient, u)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching signature %s: %v", u, err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("error fetching signature %s: unexpected status %s", u, resp.Status)
+	}
+	return readSignature(resp.Body, u)
+}
+
+func readSignature(r io.Reader, name string) ([]byte, error) {
+	sig, err := ioutil.ReadAll(io.LimitReader(r, maxSignatureSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading signature %s: %v", name, err)
+	}
+	if len(sig) > maxSignatureSize {
+		return nil, fmt.Errorf("signature %s is larger than %d bytes", name, maxSignatureSize)
+	}
+	return sig, nil
+}
@@ -0,0 +1,235 @@
+package signature
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+
+	"github.com/docker/docker/reference"
+	"github.com/docker/libtrust"
+)
+
+// Policy is the signature policy of the daemon. It defines which keys are
+// trusted to sign the images of each registry.
+type Policy struct {
+	// Default is the policy for registries which are not listed in
+	// Registries. If it is nil, images from those registries don't need
+	// to be signed.
+	Default *RegistryPolicy `json:"default,omitempty"`
+	// Registries maps registry hostnames to their policies.
+	Registries map[string]*RegistryPolicy `json:"registries,omitempty"`
+}
+
+// RegistryPolicy is the signature policy for the images of a registry.
+type RegistryPolicy struct {
+	// TrustedKeys maps the names of signers to the files holding their
+	// public keys, in PEM or JWK format. An image must be signed by at
+	// least one of these keys. If there are no trusted keys, images don't
+	// need to be signed.
+	TrustedKeys map[string]string `json:"trusted-keys,omitempty"`
+	// RequiredSigners lists the signers which must all have signed an
+	// image.
+	RequiredSigners []string `json:"required-signers,omitempty"`
+	// SignatureStore is the URL signatures are fetched from when an image
+	// is pulled. Supported schemes are http, https and file.
+	SignatureStore string `json:"signature-store,omitempty"`
+
+	keys map[string]libtrust.PublicKey
+}
+
+// LoadPolicy reads the policy from the specified file, and loads the
+// trusted keys it refers to.
+func LoadPolicy(path string) (*Policy, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var p Policy
+	if err := json.NewDecoder(f).Decode(&p); err != nil {
+		return nil, fmt.Errorf("invalid signature policy %s: %v", path, err)
+	}
+	if p.Default != nil {
+		if err := p.Default.load(); err != nil {
+			return nil, fmt.Errorf("invalid default signature policy: %v", err)
+		}
+	}
+	for hostname, rp := range p.Registries {
+		if rp == nil {
+			return nil, fmt.Errorf("invalid signature policy for %s: empty policy", hostname)
+		}
+		if err := rp.load(); err != nil {
+			return nil, fmt.Errorf("invalid signature policy for %s: %v", hostname, err)
+		}
+	}
+	return &p, nil
+}
+
+func (rp *RegistryPolicy) load() error {
+	rp.keys = make(map[string]libtrust.PublicKey)
+	for signer, path := range rp.TrustedKeys {
+		key, err := libtrust.LoadPublicKeyFile(path)
+		if err != nil {
+			return fmt.Errorf("error loading key of signer %q: %v", signer, err)
+		}
+		rp.keys[signer] = key
+	}
+	for _, signer := range rp.RequiredSigners {
+		if _, ok := rp.keys[signer]; !ok {
+			return fmt.Errorf("required signer %q has no trusted key", signer)
+		}
+	}
+	if rp.SignatureStore != "" {
+		u, err := url.Parse(rp.SignatureStore)
+		if err != nil {
+			return fmt.Errorf("invalid signature store: %v", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "file" {
+			return fmt.Errorf("unsupported signature store scheme %s", u.Scheme)
+		}
+	}
+	return nil
+}
+
+// ForRegistry returns the policy for the registry with the specified
+// hostname, or nil if the images of that registry don't need to be signed.
+func (p *Policy) ForRegistry(hostname string) *RegistryPolicy {
+	rp, ok := p.Registries[hostname]
+	if !ok {
+		rp = p.Default
+	}
+	if rp == nil || len(rp.keys) == 0 {
+		return nil
+	}
+	return rp
+}
+
+// Signers returns the names of the trusted signers of the image in the
+// record, in sorted order.
+func (rp *RegistryPolicy) Signers(r Record) []string {
+	ids := r.KeyIDs()
+	var signers []string
+	for signer, key := range rp.keys {
+		if ids[key.KeyID()] {
+			signers = append(signers, signer)
+		}
+	}
+	sort.Strings(signers)
+	return signers
+}
+
+// Verify checks that the image in the record is signed as required by the
+// policy.
+func (rp *RegistryPolicy) Verify(r Record) error {
+	signers := rp.Signers(r)
+	if len(signers) == 0 {
+		return ErrNotSigned{Repository: r.Repository, Digest: r.Digest.String()}
+	}
+	for _, required := range rp.RequiredSigners {
+		found := false
+		for _, s := range signers {
+			if s == required {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return ErrNotSigned{Repository: r.Repository, Digest: r.Digest.String(), Signer: required}
+		}
+	}
+	return nil
+}
+
+// VerifyImage checks that an image is signed as required by the policy for
+// the repository it is referenced by. records are the signatures recorded
+// for the image. If ref is nil, the image was referenced by its ID, and the
+// image is accepted if it satisfies the policy of any of the repositories it
+// was pulled from.
+func (p *Policy) VerifyImage(ref reference.Named, records []Record) error {
+	if ref != nil {
+		rp := p.ForRegistry(ref.Hostname())
+		if rp == nil {
+			return nil
+		}
+		var err error = ErrNotSigned{Repository: ref.FullName()}
+		for _, r := range records {
+			if r.Repository != ref.FullName() {
+				continue
+			}
+			if err = rp.Verify(r); err == nil {
+				return nil
+			}
+		}
+		return err
+	}
+
+	if len(records) == 0 {
+		if p.Default != nil && len(p.Default.keys) > 0 {
+			return ErrNotSigned{}
+		}
+		return nil
+	}
+	var err error
+	for _, r := range records {
+		named, perr := reference.WithName(r.Repository)
+		if perr != nil {
+			continue
+		}
+		rp := p.ForRegistry(named.Hostname())
+		if rp == nil {
+			return nil
+		}
+		if err = rp.Verify(r); err == nil {
+			return nil
+		}
+	}
+	if err == nil {
+		err = ErrNotSigned{}
+	}
+	return err
+}
+
+// VerifyBaseImage checks that the image an unsigned image referenced by ref
+// is based on, such as the base image of a built or committed image, is
+// signed as required by the policy for the registry of ref. records are the
+// signatures recorded for the base image, which are for the repositories it
+// was pulled from rather than for ref.
+func (p *Policy) VerifyBaseImage(ref reference.Named, records []Record) error {
+	rp := p.ForRegistry(ref.Hostname())
+	if rp == nil {
+		return nil
+	}
+	var err error = ErrNotSigned{Repository: ref.FullName()}
+	for _, r := range records {
+		if err = rp.Verify(r); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// ErrNotSigned is returned when an image does not have the signatures
+// required by the signature policy.
+type ErrNotSigned struct {
+	Repository string
+	Digest     string
+	// Signer is the required signer whose signature is missing, if any.
+	Signer string
+}
+
+func (e ErrNotSigned) Error() string {
+	image := "image"
+	if e.Repository != "" {
+		image = e.Repository
+		if e.Digest != "" {
+			image += "@" + e.Digest
+		}
+	}
+	if e.Signer != "" {
+		return fmt.Sprintf("signature policy: %s is not signed by required signer %q", image, e.Signer)
+	}
+	return fmt.Sprintf("signature policy: %s does not have a trusted signature", image)
+}
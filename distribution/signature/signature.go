@@ -0,0 +1,102 @@
+// Package signature implements the verification of image signatures
+// against a signature policy, which is enforced by the daemon when images
+// are pulled and when containers are created.
+package signature
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/docker/distribution/digest"
+	"github.com/docker/docker/reference"
+	"github.com/docker/libtrust"
+)
+
+// Payload is the content of an image signature. It binds the digest of an
+// image manifest to the repository the image is published in.
+type Payload struct {
+	// Repository is the fully qualified name of the repository, like
+	// "docker.io/library/ubuntu".
+	Repository string `json:"docker-reference"`
+	// Digest is the digest of the signed manifest.
+	Digest digest.Digest `json:"docker-manifest-digest"`
+}
+
+// Sign creates a signature of the manifest with the specified digest in the
+// specified repository. The signature is a libtrust JSON web signature of the
+// JSON encoded Payload.
+func Sign(key libtrust.PrivateKey, repository reference.Named, dgst digest.Digest) ([]byte, error) {
+	payload, err := json.Marshal(Payload{
+		Repository: repository.FullName(),
+		Digest:     dgst,
+	})
+	if err != nil {
+		return nil, err
+	}
+	js, err := libtrust.NewJSONSignature(payload)
+	if err != nil {
+		return nil, err
+	}
+	if err := js.Sign(key); err != nil {
+		return nil, err
+	}
+	return js.JWS()
+}
+
+// Verify checks the signature, and returns its payload along with the keys
+// which signed it.
+func Verify(signature []byte) (*Payload, []libtrust.PublicKey, error) {
+	js, err := libtrust.ParseJWS(signature)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid signature: %v", err)
+	}
+	keys, err := js.Verify()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid signature: %v", err)
+	}
+	b, err := js.Payload()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid signature payload: %v", err)
+	}
+	var payload Payload
+	if err := json.Unmarshal(b, &payload); err != nil {
+		return nil, nil, fmt.Errorf("invalid signature payload: %v", err)
+	}
+	return &payload, keys, nil
+}
+
+// Record holds the signatures of an image, as they were found when the image
+// was pulled from a repository.
+type Record struct {
+	// Repository is the fully qualified name of the repository the image
+	// was pulled from.
+	Repository string `json:"repository"`
+	// Digest is the digest of the manifest the image was pulled by.
+	Digest digest.Digest `json:"digest"`
+	// Signatures are detached signatures of the manifest, in the format
+	// created by Sign.
+	Signatures [][]byte `json:"signatures,omitempty"`
+	// ManifestKeyIDs are the IDs of the keys which signed a schema1
+	// manifest, which carries its signatures in the manifest itself.
+	ManifestKeyIDs []string `json:"manifest-key-ids,omitempty"`
+}
+
+// KeyIDs returns the IDs of the keys with a valid signature in the record.
+// Signatures which don't match the repository and digest of the record are
+// ignored.
+func (r Record) KeyIDs() map[string]bool {
+	ids := make(map[string]bool)
+	for _, id := range r.ManifestKeyIDs {
+		ids[id] = true
+	}
+	for _, sig := range r.Signatures {
+		payload, keys, err := Verify(sig)
+		if err != nil || payload.Repository != r.Repository || payload.Digest != r.Digest {
+			continue
+		}
+		for _, k := range keys {
+			ids[k.KeyID()] = true
+		}
+	}
+	return ids
+}
@@ -0,0 +1,266 @@
+package signature
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/distribution/digest"
+	"github.com/docker/docker/reference"
+	"github.com/docker/libtrust"
+	"golang.org/x/net/context"
+)
+
+var testDigest = digest.Digest("sha256:2b4a3ea7c4ab8e4e0dc2d9a1e6b11e2e8c0d9bd0e1b9d6ab2b4d2b6c8e8a1d7f")
+
+func generateKey(t *testing.T, dir, name string) (libtrust.PrivateKey, string) {
+	key, err := libtrust.GenerateECP256PrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, name+".pem")
+	if err := libtrust.SavePublicKey(path, key.PublicKey()); err != nil {
+		t.Fatal(err)
+	}
+	return key, path
+}
+
+func writePolicy(t *testing.T, dir string, p Policy) *Policy {
+	b, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "policy.json")
+	if err := ioutil.WriteFile(path, b, 0600); err != nil {
+		t.Fatal(err)
+	}
+	policy, err := LoadPolicy(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return policy
+}
+
+func TestSignAndVerify(t *testing.T) {
+	key, err := libtrust.GenerateECP256PrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo, err := reference.WithName("quay.io/foo/bar")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sig, err := Sign(key, repo, testDigest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, keys, err := Verify(sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payload.Repository != "quay.io/foo/bar" || payload.Digest != testDigest {
+		t.Fatalf("unexpected payload %+v", payload)
+	}
+	if len(keys) != 1 || keys[0].KeyID() != key.KeyID() {
+		t.Fatalf("expected signature by %s, got %v", key.KeyID(), keys)
+	}
+
+	if _, _, err := Verify([]byte("not a signature")); err == nil {
+		t.Fatal("expected an error verifying an invalid signature")
+	}
+}
+
+func TestRegistryPolicyVerify(t *testing.T) {
+	dir, err := ioutil.TempDir("", "signature-policy-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	alice, alicePath := generateKey(t, dir, "alice")
+	bob, bobPath := generateKey(t, dir, "bob")
+	mallory, err := libtrust.GenerateECP256PrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policy := writePolicy(t, dir, Policy{
+		Registries: map[string]*RegistryPolicy{
+			"quay.io": {
+				TrustedKeys:     map[string]string{"alice": alicePath, "bob": bobPath},
+				RequiredSigners: []string{"alice"},
+			},
+			"unsigned.io": {},
+		},
+	})
+
+	if policy.ForRegistry("docker.io") != nil {
+		t.Fatal("expected no policy for docker.io")
+	}
+	if policy.ForRegistry("unsigned.io") != nil {
+		t.Fatal("expected no policy for a registry without trusted keys")
+	}
+	rp := policy.ForRegistry("quay.io")
+	if rp == nil {
+		t.Fatal("expected a policy for quay.io")
+	}
+
+	repo, err := reference.WithName("quay.io/foo/bar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := reference.WithName("quay.io/foo/other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sign := func(key libtrust.PrivateKey, repo reference.Named) []byte {
+		sig, err := Sign(key, repo, testDigest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sig
+	}
+	record := func(signatures ...[]byte) Record {
+		return Record{Repository: repo.FullName(), Digest: testDigest, Signatures: signatures}
+	}
+
+	cases := []struct {
+		record Record
+		valid  bool
+	}{
+		{record(), false},
+		{record(sign(mallory, repo)), false},
+		{record(sign(bob, repo)), false},
+		{record(sign(alice, other)), false},
+		{record(sign(alice, repo)), true},
+		{record(sign(bob, repo), sign(alice, repo)), true},
+		{Record{Repository: repo.FullName(), Digest: testDigest, ManifestKeyIDs: []string{alice.KeyID()}}, true},
+	}
+	for i, c := range cases {
+		err := rp.Verify(c.record)
+		if c.valid && err != nil {
+			t.Fatalf("case %d: unexpected error: %v", i, err)
+		}
+		if !c.valid {
+			if _, ok := err.(ErrNotSigned); !ok {
+				t.Fatalf("case %d: expected ErrNotSigned, got %v", i, err)
+			}
+		}
+	}
+
+	if err := policy.VerifyImage(repo, []Record{record(sign(alice, repo))}); err != nil {
+		t.Fatal(err)
+	}
+	if err := policy.VerifyImage(other, []Record{record(sign(alice, repo))}); err == nil {
+		t.Fatal("expected signatures of another repository to be rejected")
+	}
+	if err := policy.VerifyImage(nil, []Record{record(sign(alice, repo))}); err != nil {
+		t.Fatal(err)
+	}
+	if err := policy.VerifyImage(nil, nil); err != nil {
+		t.Fatalf("expected an unsigned image to be accepted without a default policy, got %v", err)
+	}
+
+	// an image built on the signed image is verified with the policy of
+	// its own registry
+	if err := policy.VerifyBaseImage(other, []Record{record(sign(alice, repo))}); err != nil {
+		t.Fatal(err)
+	}
+	if err := policy.VerifyBaseImage(other, []Record{record(sign(bob, repo))}); err == nil {
+		t.Fatal("expected a base image without the required signer to be rejected")
+	}
+	if err := policy.VerifyBaseImage(other, nil); err == nil {
+		t.Fatal("expected an unsigned base image to be rejected")
+	}
+	local, err := reference.WithName("local/build")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := policy.VerifyBaseImage(local, nil); err != nil {
+		t.Fatalf("expected an image of a registry without policy to be accepted, got %v", err)
+	}
+}
+
+func TestLoadPolicyErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "signature-policy-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	_, alicePath := generateKey(t, dir, "alice")
+
+	invalid := []string{
+		`{"registries": {"quay.io": {"trusted-keys": {"alice": "/nonexistent"}}}}`,
+		`{"registries": {"quay.io": {"trusted-keys": {"alice": "` + alicePath + `"}, "required-signers": ["bob"]}}}`,
+		`{"default": {"trusted-keys": {"alice": "` + alicePath + `"}, "signature-store": "ftp://sigs.local"}}`,
+		`{"registries": {"quay.io": null}}`,
+		`not json`,
+	}
+	for _, p := range invalid {
+		path := filepath.Join(dir, "policy.json")
+		if err := ioutil.WriteFile(path, []byte(p), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadPolicy(path); err == nil {
+			t.Fatalf("expected an error loading policy %s", p)
+		}
+	}
+}
+
+func TestFetchSignaturesFromFileStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "signature-store-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	key, keyPath := generateKey(t, dir, "alice")
+	repo, err := reference.WithName("quay.io/foo/bar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := Sign(key, repo, testDigest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sigDir := filepath.Join(dir, "sigs", "foo", "bar@sha256="+testDigest.Hex())
+	if err := os.MkdirAll(sigDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"signature-1", "signature-2", "signature-4"} {
+		if err := ioutil.WriteFile(filepath.Join(sigDir, name), sig, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	policy := writePolicy(t, dir, Policy{
+		Default: &RegistryPolicy{
+			TrustedKeys:    map[string]string{"alice": keyPath},
+			SignatureStore: "file://" + filepath.Join(dir, "sigs"),
+		},
+	})
+	rp := policy.ForRegistry("quay.io")
+	if rp == nil {
+		t.Fatal("expected the default policy to apply")
+	}
+
+	signatures, err := rp.FetchSignatures(context.Background(), repo, testDigest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signatures) != 2 {
+		t.Fatalf("expected 2 signatures, got %d", len(signatures))
+	}
+	if err := rp.Verify(Record{Repository: repo.FullName(), Digest: testDigest, Signatures: signatures}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := policy.VerifyImage(nil, nil); err == nil {
+		t.Fatal("expected an unsigned image to be rejected by the default policy")
+	}
+}
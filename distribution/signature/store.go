@@ -0,0 +1,95 @@
+package signature
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/docker/distribution/digest"
+	"github.com/docker/docker/distribution/metadata"
+	"github.com/docker/docker/image"
+)
+
+// Store is the local signature store. It records the signatures an image
+// had when it was pulled, so that they can be checked against the signature
+// policy when a container is created from the image.
+type Store struct {
+	mu    sync.Mutex
+	store metadata.Store
+}
+
+// NewStore creates a new signature store on top of the distribution
+// metadata store.
+func NewStore(store metadata.Store) *Store {
+	return &Store{
+		store: store,
+	}
+}
+
+func (s *Store) namespace() string {
+	return "signatures-by-image"
+}
+
+func (s *Store) key(id image.ID) string {
+	return string(digest.Digest(id).Algorithm()) + "/" + digest.Digest(id).Hex()
+}
+
+// Get returns the signature records of an image.
+func (s *Store) Get(id image.ID) ([]Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.get(id)
+}
+
+func (s *Store) get(id image.ID) ([]Record, error) {
+	jsonBytes, err := s.store.Get(s.namespace(), s.key(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var records []Record
+	if err := json.Unmarshal(jsonBytes, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// Add records the signatures of an image. An existing record for the same
+// repository and digest is replaced.
+func (s *Store) Add(id image.ID, r Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records, err := s.get(id)
+	if err != nil {
+		return err
+	}
+	for i, existing := range records {
+		if existing.Repository == r.Repository && existing.Digest == r.Digest {
+			records = append(records[:i], records[i+1:]...)
+			break
+		}
+	}
+	records = append(records, r)
+
+	jsonBytes, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	return s.store.Set(s.namespace(), s.key(id), jsonBytes)
+}
+
+// Remove deletes the signature records of an image.
+func (s *Store) Remove(id image.ID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.store.Delete(s.namespace(), s.key(id)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
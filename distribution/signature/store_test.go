@@ -0,0 +1,65 @@
+package signature
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/distribution/metadata"
+	"github.com/docker/docker/image"
+)
+
+func TestStore(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "signature-store-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	metadataStore, err := metadata.NewFSMetadataStore(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := NewStore(metadataStore)
+
+	id := image.ID(testDigest)
+	records, err := store.Get(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 0 {
+		t.Fatalf("expected no records, got %v", records)
+	}
+
+	first := Record{Repository: "quay.io/foo/bar", Digest: testDigest, Signatures: [][]byte{[]byte("first")}}
+	second := Record{Repository: "docker.io/foo/bar", Digest: testDigest}
+	replaced := Record{Repository: "quay.io/foo/bar", Digest: testDigest, Signatures: [][]byte{[]byte("replaced")}}
+	for _, r := range []Record{first, second, replaced} {
+		if err := store.Add(id, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	records, err = store.Get(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []Record{second, replaced}; !reflect.DeepEqual(records, expected) {
+		t.Fatalf("expected records %v, got %v", expected, records)
+	}
+
+	if err := store.Remove(id); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Remove(id); err != nil {
+		t.Fatalf("removing missing records should not fail: %v", err)
+	}
+	records, err = store.Get(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 0 {
+		t.Fatalf("expected no records after removal, got %v", records)
+	}
+}
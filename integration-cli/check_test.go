@@ -34,6 +34,7 @@ type DockerSuite struct {
 
 func (s *DockerSuite) TearDownTest(c *check.C) {
 	unpauseAllContainers()
+	deleteAllPods()
 	deleteAllContainers()
 	deleteAllImages()
 	deleteAllVolumes()
@@ -242,6 +242,78 @@ func (s *DockerSuite) TestApiCreateDeletePredefinedNetworks(c *check.C) {
 	createDeletePredefinedNetwork(c, "host")
 }
 
+func (s *DockerSuite) TestApiNetworkUsage(c *check.C) {
+	testRequires(c, DaemonIsLinux, SameHostDaemon)
+	createNetwork(c, types.NetworkCreateRequest{
+		Name: "usage",
+		NetworkCreate: types.NetworkCreate{
+			IPAM: network.IPAM{
+				Config: []network.IPAMConfig{{Subnet: "192.178.40.0/24", AuxAddress: map[string]string{"router": "192.178.40.5"}}},
+			},
+		},
+	}, true)
+	dockerCmd(c, "run", "-d", "--name", "usage-test", "--net", "usage", "--ip", "192.178.40.10", "-p", "127.0.0.1:8123:80", "busybox", "top")
+
+	status, body, err := sockRequest("GET", "/system/network-usage", nil)
+	c.Assert(err, checker.IsNil)
+	c.Assert(status, checker.Equals, http.StatusOK)
+
+	var usage types.NetworkUsage
+	c.Assert(json.Unmarshal(body, &usage), checker.IsNil)
+
+	var pool *types.IPAMPoolUsage
+	for _, n := range usage.Networks {
+		if n.Name == "usage" {
+			c.Assert(n.Pools, checker.HasLen, 1)
+			pool = &n.Pools[0]
+		}
+	}
+	c.Assert(pool, checker.NotNil)
+	c.Assert(pool.Subnet, checker.Equals, "192.178.40.0/24")
+	c.Assert(pool.Gateway, checker.Equals, "192.178.40.1")
+	c.Assert(pool.Reserved["router"], checker.Equals, "192.178.40.5")
+	// the network and broadcast addresses, the gateway, the auxiliary
+	// address and the container address are allocated by IPAM
+	c.Assert(pool.Size, checker.Equals, uint64(256))
+	c.Assert(pool.Available, checker.Equals, uint64(251))
+	c.Assert(pool.Allocated, checker.HasLen, 1)
+	c.Assert(pool.Allocated[0].Address, checker.Equals, "192.178.40.10")
+	c.Assert(pool.Allocated[0].Name, checker.Equals, "usage-test")
+
+	var found bool
+	for _, p := range usage.Ports {
+		if p.ContainerName == "usage-test" {
+			c.Assert(p.IP, checker.Equals, "127.0.0.1")
+			c.Assert(p.PublicPort, checker.Equals, 8123)
+			c.Assert(p.PrivatePort, checker.Equals, 80)
+			found = true
+		}
+	}
+	c.Assert(found, checker.True)
+
+	status, body, err = sockRequest("GET", "/system/network-usage/check?port=8123&port=127.0.0.2:8123&port=8123/udp", nil)
+	c.Assert(err, checker.IsNil)
+	c.Assert(status, checker.Equals, http.StatusOK)
+
+	var results []types.PortCheckResult
+	c.Assert(json.Unmarshal(body, &results), checker.IsNil)
+	c.Assert(results, checker.HasLen, 3)
+	c.Assert(results[0].Available, checker.False)
+	c.Assert(results[0].ContainerName, checker.Equals, "usage-test")
+	c.Assert(results[1].Available, checker.True)
+	c.Assert(results[2].Available, checker.True)
+
+	status, _, err = sockRequest("GET", "/system/network-usage/check?port=foo", nil)
+	c.Assert(err, checker.IsNil)
+	c.Assert(status, checker.Equals, http.StatusBadRequest)
+
+	// the number of ports checked at once is limited
+	status, body, err = sockRequest("GET", "/system/network-usage/check?port=1-1000&port=2000-2100", nil)
+	c.Assert(err, checker.IsNil)
+	c.Assert(status, checker.Equals, http.StatusBadRequest)
+	c.Assert(string(body), checker.Contains, "at most 1024 ports")
+}
+
 func createDeletePredefinedNetwork(c *check.C, name string) {
 	// Create pre-defined network
 	config := types.NetworkCreateRequest{
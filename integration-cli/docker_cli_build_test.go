@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -21,6 +22,7 @@ import (
 	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/integration/checker"
 	"github.com/docker/docker/pkg/stringutils"
+	"github.com/docker/engine-api/types"
 	"github.com/go-check/check"
 )
 
@@ -6960,3 +6962,83 @@ func (s *DockerSuite) TestBuildShellWindowsPowershell(c *check.C) {
 		c.Fatalf("Line with 'John' not found in output %q", out)
 	}
 }
+
+func (s *DockerSuite) TestBuildSquashParent(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	name := "testbuildsquashparent"
+	_, err := buildImage(name, `
+		FROM busybox
+		RUN echo hello > /hello
+		RUN echo world >> /hello
+		RUN echo hello > /remove_me
+		RUN rm /remove_me
+		`, true, "--squash")
+	c.Assert(err, checker.IsNil)
+
+	out, _ := dockerCmd(c, "run", "--rm", name, "cat", "/hello")
+	c.Assert(strings.TrimSpace(out), checker.Equals, "hello\nworld")
+
+	dockerCmd(c, "run", "--rm", name, "sh", "-c", "[ ! -f /remove_me ]")
+
+	// the squashed image only adds a single layer on top of busybox
+	origLayers := inspectFieldJSON(c, "busybox", "RootFS.Layers")
+	var origDiffs, diffs []string
+	c.Assert(json.Unmarshal([]byte(origLayers), &origDiffs), checker.IsNil)
+	c.Assert(json.Unmarshal([]byte(inspectFieldJSON(c, name, "RootFS.Layers")), &diffs), checker.IsNil)
+	c.Assert(diffs, checker.HasLen, len(origDiffs)+1)
+
+	// history entries are kept, with the squashed ones marked as empty
+	out, _ = dockerCmd(c, "history", "--no-trunc", name)
+	c.Assert(out, checker.Contains, "echo hello > /remove_me")
+
+	var origHistory, history []types.ImageHistory
+	status, body, err := sockRequest("GET", "/images/busybox/history", nil)
+	c.Assert(err, checker.IsNil)
+	c.Assert(status, checker.Equals, http.StatusOK)
+	c.Assert(json.Unmarshal(body, &origHistory), checker.IsNil)
+	status, body, err = sockRequest("GET", "/images/"+name+"/history", nil)
+	c.Assert(err, checker.IsNil)
+	c.Assert(status, checker.Equals, http.StatusOK)
+	c.Assert(json.Unmarshal(body, &history), checker.IsNil)
+
+	// newest first: the merge entry, then the four squashed RUN entries
+	c.Assert(history, checker.HasLen, len(origHistory)+5)
+	c.Assert(history[0].Size, checker.GreaterThan, int64(0))
+	for _, h := range history[1:5] {
+		c.Assert(h.CreatedBy, checker.Contains, "RUN")
+		c.Assert(h.Size, checker.Equals, int64(0), check.Commentf("squashed entry %q is not empty", h.CreatedBy))
+	}
+}
+
+func (s *DockerSuite) TestCommitSquash(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	_, err := buildImage("testcommitsquashbase", `
+		FROM busybox
+		RUN echo hello > /hello
+		RUN echo world > /world
+		`, true)
+	c.Assert(err, checker.IsNil)
+
+	dangling := func() string {
+		out, _ := dockerCmd(c, "images", "-q", "--no-trunc", "-f", "dangling=true")
+		return out
+	}
+	before := dangling()
+
+	dockerCmd(c, "run", "--name", "squashme", "testcommitsquashbase", "sh", "-c", "echo foo > /foo")
+	dockerCmd(c, "commit", "--squash", "squashme", "testcommitsquash")
+
+	out, _ := dockerCmd(c, "run", "--rm", "testcommitsquash", "cat", "/hello", "/world", "/foo")
+	c.Assert(strings.TrimSpace(out), checker.Equals, "hello\nworld\nfoo")
+
+	// the build and commit layers are merged into one on top of busybox
+	var origDiffs, baseDiffs, diffs []string
+	c.Assert(json.Unmarshal([]byte(inspectFieldJSON(c, "busybox", "RootFS.Layers")), &origDiffs), checker.IsNil)
+	c.Assert(json.Unmarshal([]byte(inspectFieldJSON(c, "testcommitsquashbase", "RootFS.Layers")), &baseDiffs), checker.IsNil)
+	c.Assert(json.Unmarshal([]byte(inspectFieldJSON(c, "testcommitsquash", "RootFS.Layers")), &diffs), checker.IsNil)
+	c.Assert(baseDiffs, checker.HasLen, len(origDiffs)+2)
+	c.Assert(diffs, checker.HasLen, len(origDiffs)+1)
+
+	// the unsquashed image is not left behind
+	c.Assert(dangling(), checker.Equals, before)
+}
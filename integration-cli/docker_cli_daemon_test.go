@@ -470,6 +470,40 @@ func (s *DockerDaemonSuite) TestDaemonIPv6FixedCIDRAndMac(c *check.C) {
 	c.Assert(err, checker.IsNil)
 }
 
+// TestDaemonIP6Tables checks that when the daemon is started with --ip6tables the IPv6
+// subnet of the bridge is masqueraded and the ports published on the default address are
+// also published on the unspecified IPv6 address
+func (s *DockerDaemonSuite) TestDaemonIP6Tables(c *check.C) {
+	// IPv6 setup is messing with local bridge address.
+	testRequires(c, SameHostDaemon)
+	err := setupV6()
+	c.Assert(err, checker.IsNil)
+
+	err = s.d.StartWithBusybox("--ipv6", "--fixed-cidr-v6=fd00:d0c:1::/64", "--ip6tables")
+	c.Assert(err, checker.IsNil)
+
+	masquerade := []string{"-s", "fd00:d0c:1::/64", "!", "-o", "docker0", "-j", "MASQUERADE"}
+	c.Assert(iptables.ExistsIPV(iptables.IP6Tables, iptables.Nat, "POSTROUTING", masquerade...), checker.True)
+
+	out, err := s.d.Cmd("run", "-d", "--name=ip6tablestest", "-p", "80", "busybox", "top")
+	c.Assert(err, checker.IsNil, check.Commentf(out))
+
+	out, err = s.d.Cmd("port", "ip6tablestest", "80")
+	c.Assert(err, checker.IsNil, check.Commentf(out))
+	bindings := strings.Split(strings.TrimSpace(out), "\n")
+	c.Assert(bindings, checker.HasLen, 2, check.Commentf(out))
+	hostPort := strings.TrimPrefix(bindings[0], "0.0.0.0:")
+	c.Assert(bindings[1], checker.Equals, ":::"+hostPort)
+
+	out, err = s.d.Cmd("inspect", "--format", "{{.NetworkSettings.Networks.bridge.GlobalIPv6Address}}", "ip6tablestest")
+	c.Assert(err, checker.IsNil, check.Commentf(out))
+	dnat := []string{"-p", "tcp", "-d", "::/0", "--dport", hostPort, "-j", "DNAT", "--to-destination", net.JoinHostPort(strings.TrimSpace(out), "80"), "!", "-i", "docker0"}
+	c.Assert(iptables.ExistsIPV(iptables.IP6Tables, iptables.Nat, "DOCKER", dnat...), checker.True)
+
+	err = teardownV6()
+	c.Assert(err, checker.IsNil)
+}
+
 func (s *DockerDaemonSuite) TestDaemonLogLevelWrong(c *check.C) {
 	c.Assert(s.d.Start("--log-level=bogus"), check.NotNil, check.Commentf("Daemon shouldn't start with wrong log level"))
 }
@@ -2,7 +2,10 @@ package main
 
 import (
 	"bytes"
+	"net/http"
+	"net/http/httptest"
 	"os/exec"
+	"sync/atomic"
 
 	"github.com/docker/docker/pkg/integration/checker"
 	"github.com/go-check/check"
@@ -42,3 +45,21 @@ func (s *DockerRegistryAuthHtpasswdSuite) TestLoginToPrivateRegistryDeprecatedEm
 	// --email flag
 	dockerCmd(c, "login", "-u", s.reg.username, "-p", s.reg.password, "--email", s.reg.email, privateRegistryURL)
 }
+
+func (s *DockerDaemonSuite) TestLoginToHubDoesNotContactMirror(c *check.C) {
+	testRequires(c, SameHostDaemon)
+	var requests int32
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mirror.Close()
+
+	c.Assert(s.d.Start("--registry-mirror", mirror.URL, "--registry-mirror", "docker.io="+mirror.URL), checker.IsNil)
+
+	// the login fails, whether the official registry can be reached or not,
+	// but the credentials must not be sent to the mirror
+	out, err := s.d.Cmd("login", "-u", "mirroruser", "-p", "mirrorpassword")
+	c.Assert(err, checker.NotNil, check.Commentf(out))
+	c.Assert(atomic.LoadInt32(&requests), checker.Equals, int32(0))
+}
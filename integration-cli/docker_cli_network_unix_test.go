@@ -1672,3 +1672,26 @@ func (s *DockerNetworkSuite) TestDockerNetworkFlagAlias(c *check.C) {
 	output, status, _ = dockerCmdWithError("run", "--rm", "--network=user", "--net-alias=foo", "--network-alias=bar", "busybox", "true")
 	c.Assert(status, checker.Equals, 0, check.Commentf("unexpected status code %d (%s)", status, output))
 }
+
+func (s *DockerNetworkSuite) TestDockerNetworkUsageAndCheck(c *check.C) {
+	dockerCmd(c, "network", "create", "--subnet=172.28.30.0/24", "--gateway=172.28.30.1", "usage")
+	dockerCmd(c, "run", "-d", "--name", "usage-test", "--net", "usage", "--ip", "172.28.30.20", "-p", "127.0.0.1:8124:80", "busybox", "top")
+
+	out, _ := dockerCmd(c, "network", "usage")
+	c.Assert(out, checker.Matches, "(?s).*usage\\s+172.28.30.0/24\\s+172.28.30.1\\s+gateway.*")
+	c.Assert(out, checker.Matches, "(?s).*usage\\s+172.28.30.0/24\\s+172.28.30.20\\s+usage-test.*")
+
+	out, _ = dockerCmd(c, "network", "usage", "--ports")
+	c.Assert(out, checker.Matches, "(?s).*127.0.0.1\\s+8124\\s+tcp\\s+usage-test\\s+80.*")
+
+	out, _ = dockerCmd(c, "network", "check", "127.0.0.1:8124", "127.0.0.2:8124")
+	c.Assert(out, checker.Matches, "(?s).*127.0.0.1:8124/tcp\\s+false\\s+usage-test.*")
+	c.Assert(out, checker.Matches, "(?s).*127.0.0.2:8124/tcp\\s+true.*")
+
+	out, _ = dockerCmd(c, "network", "check", "-q", "127.0.0.1:8124", "127.0.0.2:8124")
+	c.Assert(strings.TrimSpace(out), checker.Equals, "8124")
+
+	out, _, err := dockerCmdWithError("network", "check", "8124/sctp")
+	c.Assert(err, checker.NotNil, check.Commentf(out))
+	c.Assert(out, checker.Contains, "unsupported protocol")
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/docker/docker/pkg/integration/checker"
+	"github.com/go-check/check"
+)
+
+func (s *DockerSuite) TestPodCreateInspectRemove(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+
+	out, _ := dockerCmd(c, "pod", "create", "--label", "foo=bar", "--infra-image", "busybox", "--infra-command", "top", "testpod")
+	id := strings.TrimSpace(out)
+
+	out, _ = dockerCmd(c, "pod", "inspect", "--format", "{{ .ID }} {{ .Name }} {{ .Labels.foo }} {{ .Running }}", "testpod")
+	c.Assert(strings.TrimSpace(out), checker.Equals, id+" testpod bar false")
+
+	out, _ = dockerCmd(c, "pod", "ls", "-q", "--no-trunc")
+	c.Assert(strings.TrimSpace(out), checker.Equals, id)
+
+	_, _, err := dockerCmdWithError("pod", "create", "--infra-image", "busybox", "testpod")
+	c.Assert(err, checker.NotNil, check.Commentf("expected error creating a pod with a name in use"))
+
+	dockerCmd(c, "pod", "rm", "testpod")
+	out, _ = dockerCmd(c, "ps", "-aq", "--filter", "name=testpod-infra")
+	c.Assert(strings.TrimSpace(out), checker.Equals, "")
+}
+
+func (s *DockerSuite) TestPodSharesNamespaces(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+
+	dockerCmd(c, "pod", "create", "--infra-image", "busybox", "--infra-command", "top", "testpod")
+	out, _ := dockerCmd(c, "run", "-d", "--pod", "testpod", "busybox", "top")
+	member := strings.TrimSpace(out)
+
+	// starting a container of the pod starts the infra container
+	c.Assert(inspectField(c, "testpod-infra", "State.Running"), checker.Equals, "true")
+	infraNet, _ := dockerCmd(c, "exec", "testpod-infra", "readlink", "/proc/self/ns/net")
+	memberNet, _ := dockerCmd(c, "exec", member, "readlink", "/proc/self/ns/net")
+	c.Assert(memberNet, checker.Equals, infraNet)
+	infraHostname, _ := dockerCmd(c, "exec", "testpod-infra", "hostname")
+	memberHostname, _ := dockerCmd(c, "exec", member, "hostname")
+	c.Assert(memberHostname, checker.Equals, infraHostname)
+
+	// the processes of the pod share the PID namespace of the infra container
+	out, _ = dockerCmd(c, "exec", member, "ps")
+	c.Assert(strings.Count(out, "top"), checker.Equals, 2)
+
+	out, _ = dockerCmd(c, "pod", "inspect", "--format", "{{ .Containers }}", "testpod")
+	c.Assert(strings.TrimSpace(out), checker.Equals, "["+member+"]")
+
+	_, _, err := dockerCmdWithError("run", "--pod", "testpod", "--net", "host", "busybox", "true")
+	c.Assert(err, checker.NotNil, check.Commentf("expected error joining a pod with a network mode"))
+}
+
+func (s *DockerSuite) TestPodPublishPorts(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+
+	dockerCmd(c, "pod", "create", "-p", "80", "--infra-image", "busybox", "--infra-command", "top", "testpod")
+	dockerCmd(c, "run", "-d", "--pod", "testpod", "busybox", "nc", "-ll", "-p", "80", "-e", "echo", "hello")
+
+	// the port of the pod is published by the infra container
+	out, _ := dockerCmd(c, "port", "testpod-infra", "80")
+	c.Assert(strings.TrimSpace(out), checker.Matches, `0\.0\.0\.0:\d+`)
+
+	_, _, err := dockerCmdWithError("run", "--pod", "testpod", "-p", "81", "busybox", "true")
+	c.Assert(err, checker.NotNil, check.Commentf("expected error publishing a port from a container of a pod"))
+}
+
+func (s *DockerSuite) TestPodRemoveRefused(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+
+	dockerCmd(c, "pod", "create", "--infra-image", "busybox", "--infra-command", "top", "testpod")
+	dockerCmd(c, "create", "--name", "member", "--pod", "testpod", "busybox", "top")
+
+	out, _, err := dockerCmdWithError("rm", "-f", "testpod-infra")
+	c.Assert(err, checker.NotNil, check.Commentf(out))
+	out, _, err = dockerCmdWithError("pod", "rm", "testpod")
+	c.Assert(err, checker.NotNil, check.Commentf(out))
+
+	dockerCmd(c, "pod", "rm", "-f", "testpod")
+	out, _ = dockerCmd(c, "ps", "-aq", "--filter", "name=member")
+	c.Assert(strings.TrimSpace(out), checker.Equals, "")
+}
+
+func (s *DockerSuite) TestPodStartStop(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+
+	dockerCmd(c, "pod", "create", "--infra-image", "busybox", "--infra-command", "top", "testpod")
+	dockerCmd(c, "create", "--name", "member", "--pod", "testpod", "busybox", "top")
+
+	dockerCmd(c, "pod", "start", "testpod")
+	c.Assert(inspectField(c, "testpod-infra", "State.Running"), checker.Equals, "true")
+	c.Assert(inspectField(c, "member", "State.Running"), checker.Equals, "true")
+
+	dockerCmd(c, "pod", "stop", "-t", "1", "testpod")
+	c.Assert(inspectField(c, "testpod-infra", "State.Running"), checker.Equals, "false")
+	c.Assert(inspectField(c, "member", "State.Running"), checker.Equals, "false")
+}
+
+func (s *DockerSuite) TestPodRestartMemberJoinsNewInfra(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+
+	dockerCmd(c, "pod", "create", "--infra-image", "busybox", "--infra-command", "top", "testpod")
+	dockerCmd(c, "run", "-d", "--name", "member", "--restart=always", "--pod", "testpod", "busybox", "top")
+	oldNet, _ := dockerCmd(c, "exec", "testpod-infra", "readlink", "/proc/self/ns/net")
+
+	// the member is killed with the infra container, and restarted in the
+	// namespaces of the new infra process
+	dockerCmd(c, "restart", "-t", "1", "testpod-infra")
+	infraNet, _ := dockerCmd(c, "exec", "testpod-infra", "readlink", "/proc/self/ns/net")
+	c.Assert(infraNet, checker.Not(checker.Equals), oldNet)
+
+	c.Assert(waitInspect("member", "{{ .RestartCount }} {{ .State.Running }} {{ .State.Restarting }}", "1 true false", 10*time.Second), checker.IsNil)
+	memberNet, _ := dockerCmd(c, "exec", "member", "readlink", "/proc/self/ns/net")
+	c.Assert(memberNet, checker.Equals, infraNet)
+}
+
+func (s *DockerSuite) TestPodStopRestartingMember(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+
+	dockerCmd(c, "pod", "create", "--infra-image", "busybox", "--infra-command", "top", "testpod")
+	dockerCmd(c, "run", "-d", "--name", "member", "--restart=always", "--pod", "testpod", "busybox", "false")
+
+	// the member is restarting while it waits for its next start
+	c.Assert(waitInspect("member", "{{ .State.Restarting }}", "true", 10*time.Second), checker.IsNil)
+
+	dockerCmd(c, "stop", "-t", "1", "member")
+	c.Assert(inspectField(c, "member", "State.Status"), checker.Equals, "exited")
+	count := inspectField(c, "member", "RestartCount")
+
+	// the restart was canceled
+	time.Sleep(2 * time.Second)
+	c.Assert(inspectField(c, "member", "State.Status"), checker.Equals, "exited")
+	c.Assert(inspectField(c, "member", "RestartCount"), checker.Equals, count)
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -1419,3 +1420,61 @@ func (s *DockerSuite) TestRunUserDeviceAllowed(c *check.C) {
 	out, _ := dockerCmd(c, "run", "--device", "/dev/snd/timer:w", "busybox", "cat", file)
 	c.Assert(out, checker.Contains, fmt.Sprintf("c %d:%d w", stat.Rdev/256, stat.Rdev%256))
 }
+
+func (s *DockerSuite) TestRunNetworkRate(c *check.C) {
+	testRequires(c, DaemonIsLinux, SameHostDaemon)
+
+	out, _ := dockerCmd(c, "run", "-d", "--network-rate", "egress=1mb", "--network-rate", "ingress=2mb", "busybox", "top")
+	id := strings.TrimSpace(out)
+	c.Assert(inspectField(c, id, "HostConfig.NetworkEgressRate"), checker.Equals, "1048576")
+	c.Assert(inspectField(c, id, "HostConfig.NetworkIngressRate"), checker.Equals, "2097152")
+
+	st := getNetworkStats(c, id)["eth0"]
+	c.Assert(st.TxRateLimit, checker.Equals, uint64(1048576))
+	c.Assert(st.RxRateLimit, checker.Equals, uint64(2097152))
+
+	// the ingress traffic is shaped on the host side of the veth pair, and
+	// the egress traffic on the ifb device it is redirected to
+	c.Assert(hostVethQdisc(c, id), checker.Contains, "rate 16777Kbit")
+	c.Assert(hostVethQdisc(c, id), checker.Contains, "ingress")
+	c.Assert(hostIfbQdisc(c, id), checker.Contains, "rate 8388Kbit")
+
+	// the limits cannot be removed from the container
+	out, _ = dockerCmd(c, "run", "-d", "--cap-add", "NET_ADMIN", "--network-rate", "egress=1mb", "busybox", "top")
+	id = strings.TrimSpace(out)
+	dockerCmdWithError("exec", id, "tc", "qdisc", "del", "dev", "eth0", "root")
+	c.Assert(hostIfbQdisc(c, id), checker.Contains, "rate 8388Kbit")
+
+	out, _, err := dockerCmdWithError("run", "--net=host", "--network-rate", "1mb", "busybox", "true")
+	c.Assert(err, checker.NotNil, check.Commentf(out))
+	c.Assert(out, checker.Contains, "Conflicting options: network rate limits cannot be used with the host or container network modes")
+}
+
+// hostVethQdisc returns the queueing disciplines of the host side of the
+// veth pair of the eth0 interface of a container.
+func hostVethQdisc(c *check.C, id string) string {
+	return hostQdisc(c, hostVethPeer(c, id))
+}
+
+// hostIfbQdisc returns the queueing disciplines of the ifb device shaping
+// the egress traffic of the eth0 interface of a container.
+func hostIfbQdisc(c *check.C, id string) string {
+	return hostQdisc(c, "ifb-"+strings.TrimPrefix(hostVethPeer(c, id), "veth"))
+}
+
+// hostVethPeer returns the name of the host side of the veth pair of the
+// eth0 interface of a container.
+func hostVethPeer(c *check.C, id string) string {
+	out, _ := dockerCmd(c, "exec", id, "cat", "/sys/class/net/eth0/iflink")
+	index, err := strconv.Atoi(strings.TrimSpace(out))
+	c.Assert(err, checker.IsNil)
+	peer, err := net.InterfaceByIndex(index)
+	c.Assert(err, checker.IsNil)
+	return peer.Name
+}
+
+func hostQdisc(c *check.C, dev string) string {
+	b, err := exec.Command("tc", "qdisc", "show", "dev", dev).CombinedOutput()
+	c.Assert(err, checker.IsNil, check.Commentf(string(b)))
+	return string(b)
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/distribution/digest"
+	"github.com/docker/docker/distribution/signature"
+	"github.com/docker/docker/pkg/integration/checker"
+	"github.com/docker/docker/reference"
+	"github.com/docker/libtrust"
+	"github.com/go-check/check"
+)
+
+// setupSignaturePolicy pushes an image based on busybox to the private
+// registry, signs it, and writes a policy requiring the signature of the test
+// key for all the registries. It returns the path of the policy file and the
+// name of the signed image.
+func setupSignaturePolicy(c *check.C, dir string) (string, string) {
+	// commit a new image, so that its ID differs from the unsigned busybox
+	repo := privateRegistryURL + "/dockercli/signed"
+	dockerCmd(c, "run", "--name", "signedbase", "busybox", "touch", "/signed")
+	dockerCmd(c, "commit", "signedbase", repo)
+	dockerCmd(c, "rm", "signedbase")
+	out, _ := dockerCmd(c, "push", repo)
+	dockerCmd(c, "rmi", repo)
+	matches := pushDigestRegex.FindStringSubmatch(out)
+	c.Assert(matches, checker.HasLen, 2, check.Commentf("unable to parse digest from push output: %s", out))
+	dgst := digest.Digest(matches[1])
+
+	key, err := libtrust.GenerateECP256PrivateKey()
+	c.Assert(err, checker.IsNil)
+	keyPath := filepath.Join(dir, "key.pem")
+	c.Assert(libtrust.SavePublicKey(keyPath, key.PublicKey()), checker.IsNil)
+
+	named, err := reference.ParseNamed(repo)
+	c.Assert(err, checker.IsNil)
+	sig, err := signature.Sign(key, named, dgst)
+	c.Assert(err, checker.IsNil)
+	store := filepath.Join(dir, "signatures")
+	sigDir := filepath.Join(store, named.RemoteName()+"@"+dgst.Algorithm().String()+"="+dgst.Hex())
+	c.Assert(os.MkdirAll(sigDir, 0755), checker.IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(sigDir, "signature-1"), sig, 0644), checker.IsNil)
+
+	rp := &signature.RegistryPolicy{TrustedKeys: map[string]string{"test": keyPath}}
+	policy, err := json.Marshal(signature.Policy{
+		Default: rp,
+		Registries: map[string]*signature.RegistryPolicy{
+			privateRegistryURL: {
+				TrustedKeys:    rp.TrustedKeys,
+				SignatureStore: "file://" + store,
+			},
+		},
+	})
+	c.Assert(err, checker.IsNil)
+	policyPath := filepath.Join(dir, "policy.json")
+	c.Assert(ioutil.WriteFile(policyPath, policy, 0644), checker.IsNil)
+
+	return policyPath, repo
+}
+
+func (s *DockerRegistrySuite) TestSignaturePolicyBuildAndCommit(c *check.C) {
+	testRequires(c, SameHostDaemon)
+	dir, err := ioutil.TempDir("", "signature-policy")
+	c.Assert(err, checker.IsNil)
+	defer os.RemoveAll(dir)
+
+	policyPath, repo := setupSignaturePolicy(c, dir)
+	c.Assert(s.d.StartWithBusybox("--signature-policy", policyPath), checker.IsNil)
+
+	out, err := s.d.Cmd("pull", repo)
+	c.Assert(err, checker.IsNil, check.Commentf(out))
+
+	// the intermediate images of a build with several steps are not signed,
+	// but they are based on a signed image
+	ctx := filepath.Join(dir, "ctx")
+	c.Assert(os.Mkdir(ctx, 0755), checker.IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(ctx, "Dockerfile"), []byte(fmt.Sprintf(`FROM %s
+RUN echo hello > /hello
+RUN echo world >> /hello
+ENV FOO bar
+RUN cat /hello
+`, repo)), 0644), checker.IsNil)
+	out, err = s.d.Cmd("build", "-t", "signedbuild", ctx)
+	c.Assert(err, checker.IsNil, check.Commentf(out))
+
+	out, err = s.d.Cmd("run", "--name", "signedbuild", "signedbuild", "cat", "/hello")
+	c.Assert(err, checker.IsNil, check.Commentf(out))
+	c.Assert(strings.TrimSpace(out), checker.Equals, "hello\nworld")
+
+	out, err = s.d.Cmd("commit", "signedbuild", "signedcommit")
+	c.Assert(err, checker.IsNil, check.Commentf(out))
+	out, err = s.d.Cmd("run", "--rm", "signedcommit", "true")
+	c.Assert(err, checker.IsNil, check.Commentf(out))
+
+	// busybox was loaded, so it cannot be the base image of a build, and
+	// the images committed from it are not trusted
+	c.Assert(ioutil.WriteFile(filepath.Join(ctx, "Dockerfile"), []byte(`FROM busybox
+RUN echo hello > /hello
+`), 0644), checker.IsNil)
+	out, err = s.d.Cmd("build", "-t", "unsignedbuild", ctx)
+	c.Assert(err, checker.NotNil, check.Commentf(out))
+	c.Assert(out, checker.Contains, "signature policy")
+	out, err = s.d.Cmd("images", "-q", "unsignedbuild")
+	c.Assert(err, checker.IsNil, check.Commentf(out))
+	c.Assert(strings.TrimSpace(out), checker.Equals, "")
+
+	out, err = s.d.Cmd("run", "--rm", "busybox", "true")
+	c.Assert(err, checker.NotNil, check.Commentf(out))
+	c.Assert(out, checker.Contains, "signature policy")
+
+	// the policy of the registry of the name of a committed image applies
+	// to the image it is based on
+	out, err = s.d.Cmd("tag", "signedcommit", privateRegistryURL+"/dockercli/committed")
+	c.Assert(err, checker.IsNil, check.Commentf(out))
+	out, err = s.d.Cmd("run", "--rm", privateRegistryURL+"/dockercli/committed", "true")
+	c.Assert(err, checker.IsNil, check.Commentf(out))
+}
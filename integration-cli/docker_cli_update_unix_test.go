@@ -5,6 +5,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"os/exec"
 	"strings"
 
 	"github.com/docker/docker/pkg/integration/checker"
@@ -215,3 +216,33 @@ func (s *DockerSuite) TestUpdateStats(c *check.C) {
 	c.Assert(preMemLimit, checker.Equals, curMemLimit)
 
 }
+
+func (s *DockerSuite) TestUpdateNetworkRate(c *check.C) {
+	testRequires(c, DaemonIsLinux, SameHostDaemon)
+
+	name := "test-update-network-rate"
+	dockerCmd(c, "run", "-d", "--name", name, "busybox", "top")
+	c.Assert(hostVethQdisc(c, name), checker.Not(checker.Contains), "tbf")
+
+	dockerCmd(c, "update", "--network-rate", "ingress=2mb", name)
+	c.Assert(inspectField(c, name, "HostConfig.NetworkIngressRate"), checker.Equals, "2097152")
+	c.Assert(hostVethQdisc(c, name), checker.Contains, "rate 16777Kbit")
+	c.Assert(getNetworkStats(c, name)["eth0"].RxRateLimit, checker.Equals, uint64(2097152))
+
+	dockerCmd(c, "update", "--network-rate", "ingress=-1", name)
+	c.Assert(hostVethQdisc(c, name), checker.Not(checker.Contains), "tbf")
+	c.Assert(getNetworkStats(c, name)["eth0"].RxRateLimit, checker.Equals, uint64(0))
+
+	dockerCmd(c, "update", "--network-rate", "egress=1mb", name)
+	c.Assert(hostIfbQdisc(c, name), checker.Contains, "rate 8388Kbit")
+	c.Assert(getNetworkStats(c, name)["eth0"].TxRateLimit, checker.Equals, uint64(1048576))
+	dockerCmd(c, "update", "--network-rate", "egress=-1", name)
+	c.Assert(hostVethQdisc(c, name), checker.Not(checker.Contains), "ingress")
+	out, err := exec.Command("ip", "link", "show", "ifb-"+strings.TrimPrefix(hostVethPeer(c, name), "veth")).CombinedOutput()
+	c.Assert(err, checker.NotNil, check.Commentf("expected the ifb device to be removed: %s", out))
+
+	// the limits are applied again when the container restarts
+	dockerCmd(c, "update", "--network-rate", "ingress=2mb", name)
+	dockerCmd(c, "restart", name)
+	c.Assert(hostVethQdisc(c, name), checker.Contains, "rate 16777Kbit")
+}
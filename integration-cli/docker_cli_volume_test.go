@@ -1,7 +1,10 @@
 package main
 
 import (
+	"io/ioutil"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
 	"github.com/docker/docker/pkg/integration/checker"
@@ -158,6 +161,74 @@ func (s *DockerSuite) TestVolumeCliLsFilterDangling(c *check.C) {
 
 }
 
+func (s *DockerSuite) TestVolumeCliLsFilterLabel(c *check.C) {
+	dockerCmd(c, "volume", "create", "--name", "testlabel1", "--label", "foo=bar")
+	dockerCmd(c, "volume", "create", "--name", "testlabel2", "--label", "foo=baz")
+	dockerCmd(c, "volume", "create", "--name", "testnolabel")
+
+	out, _ := dockerCmd(c, "volume", "ls", "--filter", "label=foo")
+	c.Assert(out, checker.Contains, "testlabel1\n")
+	c.Assert(out, checker.Contains, "testlabel2\n")
+	c.Assert(out, check.Not(checker.Contains), "testnolabel\n")
+
+	out, _ = dockerCmd(c, "volume", "ls", "--filter", "label=foo=bar")
+	c.Assert(out, checker.Contains, "testlabel1\n")
+	c.Assert(out, check.Not(checker.Contains), "testlabel2\n")
+	c.Assert(out, check.Not(checker.Contains), "testnolabel\n")
+}
+
+func (s *DockerSuite) TestVolumeCliLsFilterInUseBy(c *check.C) {
+	prefix, _ := getPrefixAndSlashFromDaemonPlatform()
+	dockerCmd(c, "volume", "create", "--name", "testinuseby1")
+	dockerCmd(c, "volume", "create", "--name", "testinuseby2")
+	dockerCmd(c, "create", "--name", "volume-inuseby", "-v", "testinuseby1:"+prefix+"/foo", "busybox", "true")
+
+	out, _ := dockerCmd(c, "volume", "ls", "--filter", "in-use-by=volume-inuseby")
+	c.Assert(out, checker.Contains, "testinuseby1\n")
+	c.Assert(out, check.Not(checker.Contains), "testinuseby2\n")
+
+	out, _, err := dockerCmdWithError("volume", "ls", "--filter", "in-use-by=nosuchcontainer")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "No such container")
+}
+
+func (s *DockerSuite) TestVolumeCliInspectUsageData(c *check.C) {
+	prefix, _ := getPrefixAndSlashFromDaemonPlatform()
+	dockerCmd(c, "volume", "create", "--name", "testusage")
+
+	out, _ := dockerCmd(c, "volume", "inspect", "--format={{ .UsageData.RefCount }} {{ .UsageData.LastUsed }}", "testusage")
+	c.Assert(strings.TrimSpace(out), checker.Equals, "0")
+
+	out, _ = dockerCmd(c, "run", "-d", "-v", "testusage:"+prefix+"/foo", "busybox", "true")
+	id := strings.TrimSpace(out)
+	dockerCmd(c, "wait", id)
+
+	out, _ = dockerCmd(c, "volume", "inspect", "--format={{ .UsageData.RefCount }} {{ json .UsageData.Containers }}", "testusage")
+	c.Assert(strings.TrimSpace(out), checker.Equals, `1 ["`+id+`"]`)
+
+	out, _ = dockerCmd(c, "volume", "inspect", "--format={{ .UsageData.LastUsed }}", "testusage")
+	c.Assert(strings.TrimSpace(out), checker.Not(checker.Equals), "")
+}
+
+func (s *DockerSuite) TestVolumeCliLsSize(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	dockerCmd(c, "volume", "create", "--name", "testsize")
+	dockerCmd(c, "run", "-v", "testsize:/foo", "busybox", "sh", "-c", "head -c 4096 /dev/zero > /foo/bar")
+
+	out, _ := dockerCmd(c, "volume", "ls", "--size", "--filter", "name=testsize")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	c.Assert(lines, checker.HasLen, 2, check.Commentf("\n%s", out))
+	c.Assert(lines[0], checker.Contains, "SIZE")
+	c.Assert(lines[1], checker.Contains, "kB")
+
+	// the size is only computed on request
+	out, _ = dockerCmd(c, "volume", "inspect", "--format={{ .UsageData.Size }}", "testsize")
+	c.Assert(strings.TrimSpace(out), checker.Equals, "-1")
+
+	out, _ = dockerCmd(c, "volume", "inspect", "--size", "--format={{ .UsageData.Size }}", "testsize")
+	c.Assert(strings.TrimSpace(out), checker.Not(checker.Equals), "-1")
+}
+
 func (s *DockerSuite) TestVolumeCliLsErrorWithInvalidFilterName(c *check.C) {
 	out, _, err := dockerCmdWithError("volume", "ls", "-f", "FOO=123")
 	c.Assert(err, checker.NotNil)
@@ -299,3 +370,111 @@ func (s *DockerSuite) TestVolumeCliCreateLabelMultiple(c *check.C) {
 		c.Assert(strings.TrimSpace(out), check.Equals, v)
 	}
 }
+
+func (s *DockerSuite) TestVolumeCliExportImport(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+
+	dockerCmd(c, "volume", "create", "--name", "testexport")
+	dockerCmd(c, "run", "-v", "testexport:/foo", "busybox", "sh", "-c", "echo hello > /foo/bar")
+
+	tmpDir, err := ioutil.TempDir("", "volume-export")
+	c.Assert(err, check.IsNil)
+	defer os.RemoveAll(tmpDir)
+	archive := filepath.Join(tmpDir, "testexport.tar")
+
+	dockerCmd(c, "volume", "export", "-o", archive, "testexport")
+
+	dockerCmd(c, "volume", "create", "--name", "testimport")
+	dockerCmd(c, "volume", "import", "-i", archive, "testimport")
+
+	out, _ := dockerCmd(c, "run", "-v", "testimport:/foo", "busybox", "cat", "/foo/bar")
+	c.Assert(strings.TrimSpace(out), check.Equals, "hello")
+}
+
+func (s *DockerSuite) TestVolumeCliImportInUse(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+
+	dockerCmd(c, "volume", "create", "--name", "testimport")
+	dockerCmd(c, "create", "-v", "testimport:/foo", "busybox")
+
+	tmpDir, err := ioutil.TempDir("", "volume-import")
+	c.Assert(err, check.IsNil)
+	defer os.RemoveAll(tmpDir)
+	archive := filepath.Join(tmpDir, "testimport.tar")
+
+	dockerCmd(c, "volume", "export", "-o", archive, "testimport")
+
+	out, _, err := dockerCmdWithError("volume", "import", "-i", archive, "testimport")
+	c.Assert(err, check.NotNil)
+	c.Assert(out, checker.Contains, "volume still in use")
+}
+
+func (s *DockerSuite) TestVolumeCliClone(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+
+	dockerCmd(c, "volume", "create", "--name", "testclonesrc", "--label", "foo=bar")
+	dockerCmd(c, "run", "--name", "testclone", "-v", "testclonesrc:/foo", "busybox", "sh", "-c", "echo hello > /foo/bar")
+
+	out, _, err := dockerCmdWithError("volume", "clone", "testclonesrc", "testclonedst")
+	c.Assert(err, check.NotNil)
+	c.Assert(out, checker.Contains, "volume still in use")
+	dockerCmd(c, "rm", "testclone")
+
+	out, _ = dockerCmd(c, "volume", "clone", "testclonesrc", "testclonedst")
+	c.Assert(strings.TrimSpace(out), check.Equals, "testclonedst")
+
+	out, _ = dockerCmd(c, "volume", "inspect", "--format={{ .Labels.foo }}", "testclonedst")
+	c.Assert(strings.TrimSpace(out), check.Equals, "bar")
+
+	out, _ = dockerCmd(c, "run", "-v", "testclonedst:/foo", "busybox", "cat", "/foo/bar")
+	c.Assert(strings.TrimSpace(out), check.Equals, "hello")
+
+	out, _, err = dockerCmdWithError("volume", "clone", "testclonesrc", "testclonedst")
+	c.Assert(err, check.NotNil)
+	c.Assert(out, checker.Contains, "A volume named testclonedst already exists")
+}
+
+func (s *DockerSuite) TestVolumeCliUpdate(c *check.C) {
+	dockerCmd(c, "volume", "create", "--name", "testupdate")
+
+	out, _, err := dockerCmdWithError("volume", "update", "testupdate")
+	c.Assert(err, check.NotNil)
+	c.Assert(out, checker.Contains, "--size is required")
+
+	out, _, err = dockerCmdWithError("volume", "update", "--size", "1g", "testupdate")
+	c.Assert(err, check.NotNil)
+	c.Assert(out, checker.Contains, "was not created with the size option")
+
+	out, _, err = dockerCmdWithError("volume", "update", "--size", "1g", "doesnotexist")
+	c.Assert(err, check.NotNil)
+	c.Assert(out, checker.Contains, "no such volume")
+}
+
+func (s *DockerSuite) TestVolumeCliCreateImage(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+
+	out, _, err := dockerCmdWithError("volume", "create", "--driver", "image", "--name", "doesnotexist:latest")
+	c.Assert(err, check.NotNil)
+	c.Assert(out, checker.Contains, "unable to create image volume")
+
+	dockerCmd(c, "volume", "create", "--driver", "image", "--name", "busybox:latest")
+	out, _ = dockerCmd(c, "run", "--rm", "-v", "busybox:latest:/image", "busybox", "ls", "/image/bin/busybox")
+	c.Assert(strings.TrimSpace(out), checker.Equals, "/image/bin/busybox")
+
+	out, _, err = dockerCmdWithError("run", "--rm", "-v", "busybox:latest:/image", "busybox", "touch", "/image/foo")
+	c.Assert(err, check.NotNil)
+	c.Assert(out, checker.Contains, "Read-only file system")
+
+	dockerCmd(c, "volume", "rm", "busybox:latest")
+}
+
+func (s *DockerSuite) TestVolumeCliTmpfsShared(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+
+	dockerCmd(c, "volume", "create", "--name", "testtmpfs", "--opt", "type=tmpfs", "--opt", "size=1m")
+	// the data is only kept while a container uses the volume
+	dockerCmd(c, "run", "-d", "-v", "testtmpfs:/data", "busybox", "top")
+	dockerCmd(c, "run", "--rm", "-v", "testtmpfs:/data", "busybox", "sh", "-c", "echo hello > /data/file")
+	out, _ := dockerCmd(c, "run", "--rm", "-v", "testtmpfs:/data", "busybox", "cat", "/data/file")
+	c.Assert(strings.TrimSpace(out), checker.Equals, "hello")
+}
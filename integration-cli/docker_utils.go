@@ -306,6 +306,32 @@ func getAllNetworks() ([]types.NetworkResource, error) {
 	return networks, nil
 }
 
+func deleteAllPods() error {
+	var pods []types.Pod
+	_, b, err := sockRequest("GET", "/pods/json", nil)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, &pods); err != nil {
+		return err
+	}
+	var errors []string
+	for _, p := range pods {
+		status, b, err := sockRequest("DELETE", "/pods/"+p.ID+"?force=1", nil)
+		if err != nil {
+			errors = append(errors, err.Error())
+			continue
+		}
+		if status != http.StatusNoContent {
+			errors = append(errors, fmt.Sprintf("error deleting pod %s: %s", p.Name, string(b)))
+		}
+	}
+	if len(errors) > 0 {
+		return fmt.Errorf(strings.Join(errors, "\n"))
+	}
+	return nil
+}
+
 func deleteAllVolumes() error {
 	volumes, err := getAllVolumes()
 	if err != nil {
@@ -1,6 +1,7 @@
 package layer
 
 import (
+	"archive/tar"
 	"bytes"
 	"io"
 	"io/ioutil"
@@ -766,3 +767,59 @@ func TestTarStreamVerification(t *testing.T) {
 		t.Fatalf("wrong error returned from tarstream: %q", err)
 	}
 }
+
+func TestTarStreamFrom(t *testing.T) {
+	// TODO Windows: Figure out why this is failing
+	if runtime.GOOS == "windows" {
+		t.Skip("Failing on Windows")
+	}
+	ls, _, cleanup := newTestStore(t)
+	defer cleanup()
+
+	layer1, err := createLayer(ls, "", initWithFiles(newTestFile("/base", []byte("base"), 0644)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	layer2, err := createLayer(ls, layer1.ChainID(), initWithFiles(newTestFile("/foo", []byte("foo"), 0644)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	layer3, err := createLayer(ls, layer2.ChainID(), initWithFiles(newTestFile("/bar", []byte("bar"), 0644)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts, err := layer3.TarStreamFrom(layer1.ChainID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ts.Close()
+
+	names := map[string]bool{}
+	tr := tar.NewReader(ts)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		names[filepath.Clean(hdr.Name)] = true
+	}
+
+	for _, name := range []string{"foo", "bar"} {
+		if !names[name] {
+			t.Fatalf("expected %s in the diff to the parent, got %v", name, names)
+		}
+	}
+	if names["base"] {
+		t.Fatalf("unexpected parent file in the diff to the parent: %v", names)
+	}
+
+	if _, err := layer1.TarStreamFrom(layer3.ChainID()); err == nil {
+		t.Fatal("expected an error getting a diff to a non-parent layer")
+	}
+}
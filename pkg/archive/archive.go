@@ -97,6 +97,8 @@ const (
 	Gzip
 	// Xz is xz compression algorithm.
 	Xz
+	// Zstd is zstd compression algorithm.
+	Zstd
 )
 
 const (
@@ -142,6 +144,7 @@ func DetectCompression(source []byte) Compression {
 		Bzip2: {0x42, 0x5A, 0x68},
 		Gzip:  {0x1F, 0x8B, 0x08},
 		Xz:    {0xFD, 0x37, 0x7A, 0x58, 0x5A, 0x00},
+		Zstd:  {0x28, 0xB5, 0x2F, 0xFD},
 	} {
 		if len(source) < len(m) {
 			logrus.Debug("Len too short")
@@ -160,6 +163,21 @@ func xzDecompress(archive io.Reader) (io.ReadCloser, <-chan struct{}, error) {
 	return cmdStream(exec.Command(args[0], args[1:]...), archive)
 }
 
+func zstdDecompress(archive io.Reader) (io.ReadCloser, <-chan struct{}, error) {
+	args := []string{"zstd", "-d", "-c", "-q"}
+
+	return cmdStream(exec.Command(args[0], args[1:]...), archive)
+}
+
+func zstdCompress(dest io.Writer, level int) (io.WriteCloser, error) {
+	args := []string{"zstd", "-c", "-q"}
+	if level != 0 {
+		args = append(args, fmt.Sprintf("-%d", level))
+	}
+
+	return cmdWriteStream(exec.Command(args[0], args[1:]...), dest)
+}
+
 // DecompressStream decompresses the archive and returns a ReaderCloser with the decompressed archive.
 func DecompressStream(archive io.Reader) (io.ReadCloser, error) {
 	p := pools.BufioReader32KPool
@@ -201,6 +219,16 @@ func DecompressStream(archive io.Reader) (io.ReadCloser, error) {
 			<-chdone
 			return readBufWrapper.Close()
 		}), nil
+	case Zstd:
+		zstdReader, chdone, err := zstdDecompress(buf)
+		if err != nil {
+			return nil, err
+		}
+		readBufWrapper := p.NewReadCloserWrapper(buf, zstdReader)
+		return ioutils.NewReadCloserWrapper(readBufWrapper, func() error {
+			<-chdone
+			return readBufWrapper.Close()
+		}), nil
 	default:
 		return nil, fmt.Errorf("Unsupported compression format %s", (&compression).Extension())
 	}
@@ -208,6 +236,13 @@ func DecompressStream(archive io.Reader) (io.ReadCloser, error) {
 
 // CompressStream compresseses the dest with specified compression algorithm.
 func CompressStream(dest io.Writer, compression Compression) (io.WriteCloser, error) {
+	return CompressStreamLevel(dest, compression, 0)
+}
+
+// CompressStreamLevel compresses the dest with the specified compression
+// algorithm and compression level. A level of 0 selects the default level of
+// the algorithm.
+func CompressStreamLevel(dest io.Writer, compression Compression, level int) (io.WriteCloser, error) {
 	p := pools.BufioWriter32KPool
 	buf := p.Get(dest)
 	switch compression {
@@ -215,9 +250,20 @@ func CompressStream(dest io.Writer, compression Compression) (io.WriteCloser, er
 		writeBufWrapper := p.NewWriteCloserWrapper(buf, buf)
 		return writeBufWrapper, nil
 	case Gzip:
-		gzWriter := gzip.NewWriter(dest)
+		if level == 0 {
+			level = gzip.DefaultCompression
+		}
+		gzWriter, err := gzip.NewWriterLevel(dest, level)
+		if err != nil {
+			return nil, err
+		}
 		writeBufWrapper := p.NewWriteCloserWrapper(buf, gzWriter)
 		return writeBufWrapper, nil
+	case Zstd:
+		// zstd writes its output to dest itself, and must exit before dest
+		// can be considered complete, so the buffer is not used.
+		p.Put(buf)
+		return zstdCompress(dest, level)
 	case Bzip2, Xz:
 		// archive/bzip2 does not support writing, and there is no xz support at all
 		// However, this is not a problem as docker only currently generates gzipped tars
@@ -238,10 +284,40 @@ func (compression *Compression) Extension() string {
 		return "tar.gz"
 	case Xz:
 		return "tar.xz"
+	case Zstd:
+		return "tar.zst"
 	}
 	return ""
 }
 
+// Name returns the name of the specified compression algorithm, as accepted
+// by ParseCompression.
+func (compression *Compression) Name() string {
+	switch *compression {
+	case Uncompressed:
+		return "none"
+	case Bzip2:
+		return "bzip2"
+	case Gzip:
+		return "gzip"
+	case Xz:
+		return "xz"
+	case Zstd:
+		return "zstd"
+	}
+	return ""
+}
+
+// ParseCompression returns the compression algorithm with the specified name.
+func ParseCompression(name string) (Compression, error) {
+	for _, compression := range []Compression{Uncompressed, Bzip2, Gzip, Xz, Zstd} {
+		if compression.Name() == strings.ToLower(name) {
+			return compression, nil
+		}
+	}
+	return Uncompressed, fmt.Errorf("unknown compression algorithm %q", name)
+}
+
 type tarWhiteoutConverter interface {
 	ConvertWrite(*tar.Header, string, os.FileInfo) (*tar.Header, error)
 	ConvertRead(*tar.Header, string) (bool, error)
@@ -1109,6 +1185,29 @@ func cmdStream(cmd *exec.Cmd, input io.Reader) (io.ReadCloser, <-chan struct{},
 	return pipeR, chdone, nil
 }
 
+// cmdWriteStream executes a command, and returns a WriteCloser which feeds
+// the command's standard input, with the command's output written to output.
+// Closing the WriteCloser waits for the command to exit.
+func cmdWriteStream(cmd *exec.Cmd, output io.Writer) (io.WriteCloser, error) {
+	pipeR, pipeW := io.Pipe()
+	cmd.Stdin = pipeR
+	cmd.Stdout = output
+	var errBuf bytes.Buffer
+	cmd.Stderr = &errBuf
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	return ioutils.NewWriteCloserWrapper(pipeW, func() error {
+		pipeW.Close()
+		if err := cmd.Wait(); err != nil {
+			return fmt.Errorf("%s: %s", err, errBuf.String())
+		}
+		return nil
+	}), nil
+}
+
 // NewTempArchive reads the content of src into a temporary file, and returns the contents
 // of that file as an archive. The archive can only be read once - as soon as reading completes,
 // the file will be deleted.
@@ -136,6 +136,90 @@ func TestDecompressStreamXz(t *testing.T) {
 	}
 }
 
+func TestDecompressStreamZstd(t *testing.T) {
+	if _, err := exec.LookPath("zstd"); err != nil {
+		t.Skip("zstd not installed")
+	}
+	tmp, err := ioutil.TempDir("", "docker-test-zstd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	src := filepath.Join(tmp, "archive")
+	if err := ioutil.WriteFile(src, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command("zstd", "-q", "-f", "--rm", src)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("Fail to create an archive file for test : %s.", output)
+	}
+	archive, err := os.Open(src + ".zst")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer archive.Close()
+	_, err = DecompressStream(archive)
+	if err != nil {
+		t.Fatalf("Failed to decompress a zstd file.")
+	}
+}
+
+func TestCompressStreamLevelRoundTrip(t *testing.T) {
+	for _, compression := range []Compression{Gzip, Zstd} {
+		if compression == Zstd {
+			if _, err := exec.LookPath("zstd"); err != nil {
+				continue
+			}
+		}
+		content := bytes.Repeat([]byte("docker"), 4096)
+
+		buf := new(bytes.Buffer)
+		w, err := CompressStreamLevel(buf, compression, 9)
+		if err != nil {
+			t.Fatalf("Failed to create a %s compressor: %v", compression.Name(), err)
+		}
+		if _, err := w.Write(content); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		if detected := DetectCompression(buf.Bytes()); detected != compression {
+			t.Fatalf("Expected %s compression to be detected, got %s", compression.Name(), detected.Name())
+		}
+
+		r, err := DecompressStream(buf)
+		if err != nil {
+			t.Fatalf("Failed to decompress a %s stream: %v", compression.Name(), err)
+		}
+		decompressed, err := ioutil.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(decompressed, content) {
+			t.Fatalf("Unexpected content after a %s round trip", compression.Name())
+		}
+	}
+}
+
+func TestParseCompression(t *testing.T) {
+	for _, name := range []string{"none", "gzip", "ZSTD"} {
+		compression, err := ParseCompression(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if compression.Name() != strings.ToLower(name) {
+			t.Fatalf("Expected %s, got %s", name, compression.Name())
+		}
+	}
+	if _, err := ParseCompression("lz4"); err == nil {
+		t.Fatal("Expected an error parsing an unknown compression algorithm")
+	}
+}
+
 func TestCompressStreamXzUnsuported(t *testing.T) {
 	dest, err := os.Create(tmp + "dest")
 	if err != nil {
@@ -205,6 +289,13 @@ func TestExtensionXz(t *testing.T) {
 		t.Fatalf("The extension of a bzip2 archive should be 'tar.xz'")
 	}
 }
+func TestExtensionZstd(t *testing.T) {
+	compression := Zstd
+	output := compression.Extension()
+	if output != "tar.zst" {
+		t.Fatalf("The extension of a zstd archive should be 'tar.zst'")
+	}
+}
 
 func TestCmdStreamLargeStderr(t *testing.T) {
 	cmd := exec.Command("sh", "-c", "dd if=/dev/zero bs=1k count=1000 of=/dev/stderr; echo hello")
@@ -68,13 +68,23 @@ type Client struct {
 // Call calls the specified method with the specified arguments for the plugin.
 // It will retry for 30 seconds if a failure occurs when calling.
 func (c *Client) Call(serviceMethod string, args interface{}, ret interface{}) error {
+	return c.call(serviceMethod, args, ret, true)
+}
+
+// CallNoRetry calls the specified method with the specified arguments for the
+// plugin, and fails right away if the plugin can't be reached.
+func (c *Client) CallNoRetry(serviceMethod string, args interface{}, ret interface{}) error {
+	return c.call(serviceMethod, args, ret, false)
+}
+
+func (c *Client) call(serviceMethod string, args interface{}, ret interface{}, retry bool) error {
 	var buf bytes.Buffer
 	if args != nil {
 		if err := json.NewEncoder(&buf).Encode(args); err != nil {
 			return err
 		}
 	}
-	body, err := c.callWithRetry(serviceMethod, &buf, true)
+	body, err := c.callWithRetry(serviceMethod, &buf, retry)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,169 @@
+// Package pod manages groups of containers that share the network, IPC,
+// PID and UTS namespaces of an infra container.
+package pod
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/pkg/ioutils"
+)
+
+const configFileName = "config.json"
+
+var (
+	// ErrNotFound is returned when no pod matches the given name or ID.
+	ErrNotFound = errors.New("no such pod")
+	// ErrNameConflict is returned when a pod with the same name exists.
+	ErrNameConflict = errors.New("pod name is already in use")
+)
+
+// Pod is a group of containers that share the namespaces of the infra
+// container of the pod. The infra container runs a process that does
+// nothing but hold the namespaces, so that they outlive the containers
+// of the pod.
+type Pod struct {
+	ID      string
+	Name    string
+	Created time.Time
+	Labels  map[string]string
+	// InfraContainerID is the ID of the container holding the namespaces
+	// of the pod.
+	InfraContainerID string
+}
+
+// Store keeps the pods in memory, and persists them in a directory.
+type Store struct {
+	mu   sync.RWMutex
+	root string
+	pods map[string]*Pod
+}
+
+// NewStore returns a store persisting pods under root, loaded with the
+// pods stored there.
+func NewStore(root string) (*Store, error) {
+	if err := os.MkdirAll(root, 0700); err != nil {
+		return nil, err
+	}
+	s := &Store{
+		root: root,
+		pods: make(map[string]*Pod),
+	}
+
+	dirs, err := ioutil.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range dirs {
+		if !d.IsDir() {
+			continue
+		}
+		b, err := ioutil.ReadFile(filepath.Join(root, d.Name(), configFileName))
+		if err != nil {
+			logrus.Errorf("Error reading pod %s: %v", d.Name(), err)
+			continue
+		}
+		var p Pod
+		if err := json.Unmarshal(b, &p); err != nil {
+			logrus.Errorf("Error reading pod %s: %v", d.Name(), err)
+			continue
+		}
+		s.pods[p.ID] = &p
+	}
+	return s, nil
+}
+
+// Add persists the pod and adds it to the store.
+func (s *Store) Add(p *Pod) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, other := range s.pods {
+		if other.Name == p.Name {
+			return ErrNameConflict
+		}
+	}
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(s.root, p.ID)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	if err := ioutils.AtomicWriteFile(filepath.Join(dir, configFileName), b, 0600); err != nil {
+		os.RemoveAll(dir)
+		return err
+	}
+	s.pods[p.ID] = p
+	return nil
+}
+
+// Get returns the pod with the given name, ID or unique prefix of an ID.
+func (s *Store) Get(nameOrID string) (*Pod, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if p, exists := s.pods[nameOrID]; exists {
+		return p, nil
+	}
+
+	var match *Pod
+	for _, p := range s.pods {
+		if p.Name == nameOrID {
+			return p, nil
+		}
+		if nameOrID != "" && strings.HasPrefix(p.ID, nameOrID) {
+			if match != nil {
+				return nil, fmt.Errorf("multiple pods found with provided prefix: %s", nameOrID)
+			}
+			match = p
+		}
+	}
+	if match == nil {
+		return nil, ErrNotFound
+	}
+	return match, nil
+}
+
+// GetByInfraContainer returns the pod whose infra container has the given
+// ID, or nil if the container is not the infra container of a pod.
+func (s *Store) GetByInfraContainer(id string) *Pod {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, p := range s.pods {
+		if p.InfraContainerID == id {
+			return p
+		}
+	}
+	return nil
+}
+
+// List returns the pods in the store.
+func (s *Store) List() []*Pod {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	pods := make([]*Pod, 0, len(s.pods))
+	for _, p := range s.pods {
+		pods = append(pods, p)
+	}
+	return pods
+}
+
+// Delete removes the pod from the store and from disk.
+func (s *Store) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.RemoveAll(filepath.Join(s.root, id)); err != nil {
+		return err
+	}
+	delete(s.pods, id)
+	return nil
+}
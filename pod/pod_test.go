@@ -0,0 +1,75 @@
+package pod
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestStore(t *testing.T) {
+	root, err := ioutil.TempDir("", "pod-store-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	s, err := NewStore(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, p := range []*Pod{
+		{ID: "abc123", Name: "web", Created: time.Now(), InfraContainerID: "infra1"},
+		{ID: "abd456", Name: "db", Created: time.Now(), InfraContainerID: "infra2"},
+	} {
+		if err := s.Add(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Add(&Pod{ID: "xyz", Name: "web"}); err != ErrNameConflict {
+		t.Fatalf("expected %v, got %v", ErrNameConflict, err)
+	}
+
+	s, err = NewStore(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.List()) != 2 {
+		t.Fatalf("expected 2 pods after reload, got %d", len(s.List()))
+	}
+
+	for _, nameOrID := range []string{"abc123", "web", "abc"} {
+		p, err := s.Get(nameOrID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.ID != "abc123" {
+			t.Fatalf("expected %s to match pod abc123, got %s", nameOrID, p.ID)
+		}
+	}
+	if _, err := s.Get("ab"); err == nil || err == ErrNotFound {
+		t.Fatalf("expected an ambiguous prefix error, got %v", err)
+	}
+	if _, err := s.Get("nothere"); err != ErrNotFound {
+		t.Fatalf("expected %v, got %v", ErrNotFound, err)
+	}
+
+	if p := s.GetByInfraContainer("infra2"); p == nil || p.Name != "db" {
+		t.Fatalf("expected pod db for infra container infra2, got %v", p)
+	}
+	if p := s.GetByInfraContainer("other"); p != nil {
+		t.Fatalf("expected no pod, got %v", p)
+	}
+
+	if err := s.Delete("abc123"); err != nil {
+		t.Fatal(err)
+	}
+	s, err = NewStore(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Get("web"); err != ErrNotFound {
+		t.Fatalf("expected deleted pod to be gone after reload, got %v", err)
+	}
+}
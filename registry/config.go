@@ -1,10 +1,12 @@
 package registry
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
 	"net/url"
+	"sort"
 	"strings"
 
 	"github.com/docker/docker/opts"
@@ -15,7 +17,7 @@ import (
 
 // ServiceOptions holds command line options.
 type ServiceOptions struct {
-	Mirrors            []string `json:"registry-mirrors,omitempty"`
+	Mirrors            Mirrors  `json:"registry-mirrors,omitempty"`
 	InsecureRegistries []string `json:"insecure-registries,omitempty"`
 
 	// V2Only controls access to legacy registries.  If it is set to true via the
@@ -23,10 +25,52 @@ type ServiceOptions struct {
 	V2Only bool `json:"disable-legacy-registry,omitempty"`
 }
 
+// Mirrors is a list of registry mirrors. Each entry is either the URL of a
+// mirror of the official registry, or the hostname of another registry and
+// the URL of a mirror for it, separated by "=", like
+// "quay.io=https://mirror.example.com". Mirrors are tried in the order in
+// which they are listed.
+type Mirrors []string
+
+// UnmarshalJSON decodes the mirrors from the configuration file, which are
+// either a list of mirrors of the official registry, or a map of registry
+// hostnames to their lists of mirrors.
+func (m *Mirrors) UnmarshalJSON(b []byte) error {
+	var list []string
+	if err := json.Unmarshal(b, &list); err == nil {
+		*m = list
+		return nil
+	}
+
+	var byRegistry map[string][]string
+	if err := json.Unmarshal(b, &byRegistry); err != nil {
+		return fmt.Errorf("registry-mirrors must be a list of mirrors, or a map of registries to lists of mirrors: %v", err)
+	}
+
+	registries := make([]string, 0, len(byRegistry))
+	for r := range byRegistry {
+		registries = append(registries, r)
+	}
+	sort.Strings(registries)
+
+	var mirrors []string
+	for _, r := range registries {
+		for _, mirror := range byRegistry[r] {
+			mirrors = append(mirrors, r+"="+mirror)
+		}
+	}
+	*m = mirrors
+	return nil
+}
+
 // serviceConfig holds daemon configuration for the registry service.
 type serviceConfig struct {
 	registrytypes.ServiceConfig
 	V2Only bool
+
+	// registryMirrors maps the hostnames of registries other than the
+	// official one to their mirrors.
+	registryMirrors map[string][]string
 }
 
 var (
@@ -71,8 +115,8 @@ var lookupIP = net.LookupIP
 // InstallCliFlags adds command-line options to the top-level flag parser for
 // the current process.
 func (options *ServiceOptions) InstallCliFlags(cmd *flag.FlagSet, usageFn func(string) string) {
-	mirrors := opts.NewNamedListOptsRef("registry-mirrors", &options.Mirrors, ValidateMirror)
-	cmd.Var(mirrors, []string{"-registry-mirror"}, usageFn("Preferred Docker registry mirror"))
+	mirrors := opts.NewNamedListOptsRef("registry-mirrors", (*[]string)(&options.Mirrors), ValidateMirror)
+	cmd.Var(mirrors, []string{"-registry-mirror"}, usageFn("Preferred Docker registry mirror, or registry=mirror for other registries"))
 
 	insecureRegistries := opts.NewNamedListOptsRef("insecure-registries", &options.InsecureRegistries, ValidateIndexName)
 	cmd.Var(insecureRegistries, []string{"-insecure-registry"}, usageFn("Enable insecure registry communication"))
@@ -93,11 +137,22 @@ func newServiceConfig(options ServiceOptions) *serviceConfig {
 		ServiceConfig: registrytypes.ServiceConfig{
 			InsecureRegistryCIDRs: make([]*registrytypes.NetIPNet, 0),
 			IndexConfigs:          make(map[string]*registrytypes.IndexInfo, 0),
-			// Hack: Bypass setting the mirrors to IndexConfigs since they are going away
-			// and Mirrors are only for the official registry anyways.
-			Mirrors: options.Mirrors,
+			// Hack: Bypass setting the mirrors to IndexConfigs since they are going away.
+			// Mirrors only lists the mirrors of the official registry.
+			Mirrors: make([]string, 0),
 		},
-		V2Only: options.V2Only,
+		V2Only:          options.V2Only,
+		registryMirrors: make(map[string][]string),
+	}
+	// Split --registry-mirror into mirrors of the official registry and
+	// mirrors of other registries.
+	for _, m := range options.Mirrors {
+		registry, mirror := splitMirror(m)
+		if registry == "" {
+			config.Mirrors = append(config.Mirrors, mirror)
+			continue
+		}
+		config.registryMirrors[registry] = append(config.registryMirrors[registry], mirror)
 	}
 	// Split --insecure-registry into CIDR and registry-specific settings.
 	for _, r := range options.InsecureRegistries {
@@ -110,7 +165,7 @@ func newServiceConfig(options ServiceOptions) *serviceConfig {
 			// Assume `host:port` if not CIDR.
 			config.IndexConfigs[r] = &registrytypes.IndexInfo{
 				Name:     r,
-				Mirrors:  make([]string, 0),
+				Mirrors:  config.mirrorsFor(r),
 				Secure:   false,
 				Official: false,
 			}
@@ -128,6 +183,31 @@ func newServiceConfig(options ServiceOptions) *serviceConfig {
 	return config
 }
 
+// splitMirror splits a --registry-mirror value into the hostname of the
+// registry and the URL of the mirror. The hostname is empty for mirrors of
+// the official registry.
+func splitMirror(val string) (registry, mirror string) {
+	i := strings.Index(val, "=")
+	if i == -1 {
+		return "", val
+	}
+	registry, mirror = val[:i], val[i+1:]
+	if registry == IndexName || registry == reference.LegacyDefaultHostname {
+		registry = ""
+	}
+	return registry, mirror
+}
+
+// mirrorsFor returns the mirrors configured for the registry with the
+// specified hostname.
+func (config *serviceConfig) mirrorsFor(indexName string) []string {
+	if indexName == IndexName || indexName == reference.LegacyDefaultHostname {
+		return config.Mirrors
+	}
+	mirrors := make([]string, 0, len(config.registryMirrors[indexName]))
+	return append(mirrors, config.registryMirrors[indexName]...)
+}
+
 // isSecureIndex returns false if the provided indexName is part of the list of insecure registries
 // Insecure registries accept HTTP and/or accept HTTPS with certificates from unknown CAs.
 //
@@ -177,8 +257,24 @@ func isSecureIndex(config *serviceConfig, indexName string) bool {
 	return true
 }
 
-// ValidateMirror validates an HTTP(S) registry mirror
+// ValidateMirror validates an HTTP(S) registry mirror, optionally preceded
+// by the hostname of the registry it mirrors and "=".
 func ValidateMirror(val string) (string, error) {
+	if i := strings.Index(val, "="); i != -1 {
+		registry, err := ValidateIndexName(val[:i])
+		if err != nil {
+			return "", err
+		}
+		if registry == "" || strings.Contains(registry, "/") || strings.Contains(val[i+1:], "=") {
+			return "", fmt.Errorf("Invalid registry hostname for mirror %s", val)
+		}
+		mirror, err := ValidateMirror(val[i+1:])
+		if err != nil {
+			return "", err
+		}
+		return registry + "=" + mirror, nil
+	}
+
 	uri, err := url.Parse(val)
 	if err != nil {
 		return "", fmt.Errorf("%s is not a valid URI", val)
@@ -230,7 +326,7 @@ func newIndexInfo(config *serviceConfig, indexName string) (*registrytypes.Index
 	// Construct a non-configured index info.
 	index := &registrytypes.IndexInfo{
 		Name:     indexName,
-		Mirrors:  make([]string, 0),
+		Mirrors:  config.mirrorsFor(indexName),
 		Official: false,
 	}
 	index.Secure = isSecureIndex(config, indexName)
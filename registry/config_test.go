@@ -1,6 +1,8 @@
 package registry
 
 import (
+	"encoding/json"
+	"reflect"
 	"testing"
 )
 
@@ -16,6 +18,9 @@ func TestValidateMirror(t *testing.T) {
 		"https://127.0.0.1",
 		"http://127.0.0.1:5000",
 		"https://127.0.0.1:5000",
+		"quay.io=https://mirror-1.com",
+		"localhost:5000=http://mirror-1.com",
+		"docker.io=https://mirror-1.com",
 	}
 
 	invalid := []string{
@@ -33,6 +38,12 @@ func TestValidateMirror(t *testing.T) {
 		"https://mirror-1.com/v1/",
 		"https://mirror-1.com/v1/#",
 		"https://mirror-1.com?q",
+		"quay.io=ftp://mirror-1.com",
+		"quay.io=https://mirror-1.com/v1/",
+		"=https://mirror-1.com",
+		"quay.io/foo=https://mirror-1.com",
+		"-quay.io=https://mirror-1.com",
+		"quay.io=gcr.io=https://mirror-1.com",
 	}
 
 	for _, address := range valid {
@@ -47,3 +58,62 @@ func TestValidateMirror(t *testing.T) {
 		}
 	}
 }
+
+func TestMirrorsUnmarshalJSON(t *testing.T) {
+	cases := map[string]Mirrors{
+		`[]`: {},
+		`["https://mirror-1.com", "https://mirror-2.com"]`: {"https://mirror-1.com", "https://mirror-2.com"},
+		`{"quay.io": ["https://mirror-1.com", "https://mirror-2.com"], "docker.io": ["https://mirror-3.com"]}`: {
+			"docker.io=https://mirror-3.com",
+			"quay.io=https://mirror-1.com",
+			"quay.io=https://mirror-2.com",
+		},
+	}
+
+	for config, expected := range cases {
+		var m Mirrors
+		if err := json.Unmarshal([]byte(config), &m); err != nil {
+			t.Fatalf("error decoding %s: %v", config, err)
+		}
+		if len(m) != len(expected) || (len(m) > 0 && !reflect.DeepEqual(m, expected)) {
+			t.Fatalf("decoding %s: expected %v, got %v", config, expected, m)
+		}
+	}
+
+	var m Mirrors
+	if err := json.Unmarshal([]byte(`"https://mirror-1.com"`), &m); err == nil {
+		t.Fatal("expected an error decoding a single mirror")
+	}
+}
+
+func TestNewServiceConfigRegistryMirrors(t *testing.T) {
+	config := newServiceConfig(ServiceOptions{
+		Mirrors: Mirrors{
+			"https://hub-mirror.local/",
+			"quay.io=https://quay-mirror-1.local/",
+			"index.docker.io=https://hub-mirror-2.local/",
+			"quay.io=https://quay-mirror-2.local/",
+		},
+		InsecureRegistries: []string{"insecure.local"},
+	})
+
+	if expected := []string{"https://hub-mirror.local/", "https://hub-mirror-2.local/"}; !reflect.DeepEqual(config.Mirrors, expected) {
+		t.Fatalf("expected official registry mirrors %v, got %v", expected, config.Mirrors)
+	}
+
+	index, err := newIndexInfo(config, "quay.io")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"https://quay-mirror-1.local/", "https://quay-mirror-2.local/"}; !reflect.DeepEqual(index.Mirrors, expected) {
+		t.Fatalf("expected quay.io mirrors %v, got %v", expected, index.Mirrors)
+	}
+
+	index, err = newIndexInfo(config, "insecure.local")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(index.Mirrors) != 0 {
+		t.Fatalf("expected no mirrors for insecure.local, got %v", index.Mirrors)
+	}
+}
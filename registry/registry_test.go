@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -684,6 +685,74 @@ func TestMirrorEndpointLookup(t *testing.T) {
 	}
 }
 
+func TestRegistryMirrorEndpointLookup(t *testing.T) {
+	s := DefaultService{config: makeServiceConfig([]string{
+		"quay.io=https://mirror-1.local/",
+		"quay.io=https://mirror-2.local/",
+		"other.io=https://mirror-3.local/",
+	}, nil)}
+
+	pullAPIEndpoints, err := s.LookupPullEndpoints("quay.io")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var hosts []string
+	for _, e := range pullAPIEndpoints {
+		if e.Version == APIVersion2 {
+			hosts = append(hosts, e.URL.Host)
+		}
+	}
+	if expected := []string{"mirror-1.local", "mirror-2.local", "quay.io"}; !reflect.DeepEqual(hosts, expected) {
+		t.Fatalf("expected v2 pull endpoints %v, got %v", expected, hosts)
+	}
+	if !pullAPIEndpoints[0].Mirror || !pullAPIEndpoints[1].Mirror || pullAPIEndpoints[2].Mirror {
+		t.Fatal("only the mirror endpoints should be marked as mirrors")
+	}
+
+	pushAPIEndpoints, err := s.LookupPushEndpoints("quay.io")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range pushAPIEndpoints {
+		if e.Mirror {
+			t.Fatalf("push endpoints should not contain mirror %s", e.URL)
+		}
+	}
+
+	authEndpoints, err := s.lookupAuthEndpoints("quay.io")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(authEndpoints) != len(pullAPIEndpoints) {
+		t.Fatalf("expected %d auth endpoints, got %d", len(pullAPIEndpoints), len(authEndpoints))
+	}
+	if authEndpoints[0].Mirror || !authEndpoints[len(authEndpoints)-1].Mirror {
+		t.Fatal("auth endpoints should try the registry before its mirrors")
+	}
+}
+
+func TestRegistryMirrorAuthEndpointLookupOfficial(t *testing.T) {
+	s := DefaultService{config: makeServiceConfig([]string{
+		"https://mirror-1.local/",
+		"docker.io=https://mirror-2.local/",
+	}, nil)}
+
+	for _, hostname := range []string{DefaultNamespace, DefaultV1Registry.Host} {
+		authEndpoints, err := s.lookupAuthEndpoints(hostname)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(authEndpoints) == 0 {
+			t.Fatalf("expected auth endpoints for %s", hostname)
+		}
+		for _, e := range authEndpoints {
+			if e.Mirror || strings.HasPrefix(e.URL.Host, "mirror-") {
+				t.Fatalf("credentials for %s should not be sent to mirror %s", hostname, e.URL)
+			}
+		}
+	}
+}
+
 func TestPushRegistryTag(t *testing.T) {
 	r := spawnTestRegistrySession(t)
 	repoRef, err := reference.ParseNamed(REPO)
@@ -69,7 +69,7 @@ func (s *DefaultService) Auth(ctx context.Context, authConfig *types.AuthConfig,
 		return "", "", fmt.Errorf("unable to parse server address: %v", err)
 	}
 
-	endpoints, err := s.LookupPushEndpoints(u.Host)
+	endpoints, err := s.lookupAuthEndpoints(u.Host)
 	if err != nil {
 		return "", "", err
 	}
@@ -220,7 +220,8 @@ func (s *DefaultService) tlsConfigForMirror(mirrorURL *url.URL) (*tls.Config, er
 
 // LookupPullEndpoints creates a list of endpoints to try to pull from, in order of preference.
 // It gives preference to v2 endpoints over v1, mirrors over the actual
-// registry, and HTTPS over plain HTTP.
+// registry, and HTTPS over plain HTTP. Mirrors are tried in the order in
+// which they are configured for the registry.
 func (s *DefaultService) LookupPullEndpoints(hostname string) (endpoints []APIEndpoint, err error) {
 	return s.lookupEndpoints(hostname)
 }
@@ -240,6 +241,29 @@ func (s *DefaultService) LookupPushEndpoints(hostname string) (endpoints []APIEn
 	return endpoints, err
 }
 
+// lookupAuthEndpoints creates a list of endpoints to try to log in to, in
+// order of preference. The registry itself is preferred, the mirrors
+// configured for that registry are only tried if it can't be reached, so
+// that the credentials can still be validated when the registry is only
+// accessible through its mirrors. Credentials are never sent to the mirrors
+// of the official registry, which are usually run by third parties.
+func (s *DefaultService) lookupAuthEndpoints(hostname string) (endpoints []APIEndpoint, err error) {
+	allEndpoints, err := s.lookupEndpoints(hostname)
+	if err != nil {
+		return nil, err
+	}
+	official := hostname == DefaultNamespace || hostname == DefaultV1Registry.Host
+	var mirrors []APIEndpoint
+	for _, endpoint := range allEndpoints {
+		if !endpoint.Mirror {
+			endpoints = append(endpoints, endpoint)
+		} else if !official {
+			mirrors = append(mirrors, endpoint)
+		}
+	}
+	return append(endpoints, mirrors...), nil
+}
+
 func (s *DefaultService) lookupEndpoints(hostname string) (endpoints []APIEndpoint, err error) {
 	endpoints, err = s.lookupV2Endpoints(hostname)
 	if err != nil {
@@ -12,26 +12,9 @@ func (s *DefaultService) lookupV2Endpoints(hostname string) (endpoints []APIEndp
 	tlsConfig := &cfg
 	if hostname == DefaultNamespace || hostname == DefaultV1Registry.Host {
 		// v2 mirrors
-		for _, mirror := range s.config.Mirrors {
-			if !strings.HasPrefix(mirror, "http://") && !strings.HasPrefix(mirror, "https://") {
-				mirror = "https://" + mirror
-			}
-			mirrorURL, err := url.Parse(mirror)
-			if err != nil {
-				return nil, err
-			}
-			mirrorTLSConfig, err := s.tlsConfigForMirror(mirrorURL)
-			if err != nil {
-				return nil, err
-			}
-			endpoints = append(endpoints, APIEndpoint{
-				URL: mirrorURL,
-				// guess mirrors are v2
-				Version:      APIVersion2,
-				Mirror:       true,
-				TrimHostname: true,
-				TLSConfig:    mirrorTLSConfig,
-			})
+		endpoints, err = s.lookupV2MirrorEndpoints(s.config.Mirrors)
+		if err != nil {
+			return nil, err
 		}
 		// v2 registry
 		endpoints = append(endpoints, APIEndpoint{
@@ -50,7 +33,13 @@ func (s *DefaultService) lookupV2Endpoints(hostname string) (endpoints []APIEndp
 		return nil, err
 	}
 
-	endpoints = []APIEndpoint{
+	// v2 mirrors
+	endpoints, err = s.lookupV2MirrorEndpoints(s.config.registryMirrors[hostname])
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints = append(endpoints, []APIEndpoint{
 		{
 			URL: &url.URL{
 				Scheme: "https",
@@ -60,7 +49,7 @@ func (s *DefaultService) lookupV2Endpoints(hostname string) (endpoints []APIEndp
 			TrimHostname: true,
 			TLSConfig:    tlsConfig,
 		},
-	}
+	}...)
 
 	if tlsConfig.InsecureSkipVerify {
 		endpoints = append(endpoints, APIEndpoint{
@@ -77,3 +66,30 @@ func (s *DefaultService) lookupV2Endpoints(hostname string) (endpoints []APIEndp
 
 	return endpoints, nil
 }
+
+// lookupV2MirrorEndpoints returns the endpoints for the specified mirrors,
+// in the order in which they are configured.
+func (s *DefaultService) lookupV2MirrorEndpoints(mirrors []string) (endpoints []APIEndpoint, err error) {
+	for _, mirror := range mirrors {
+		if !strings.HasPrefix(mirror, "http://") && !strings.HasPrefix(mirror, "https://") {
+			mirror = "https://" + mirror
+		}
+		mirrorURL, err := url.Parse(mirror)
+		if err != nil {
+			return nil, err
+		}
+		mirrorTLSConfig, err := s.tlsConfigForMirror(mirrorURL)
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, APIEndpoint{
+			URL: mirrorURL,
+			// guess mirrors are v2
+			Version:      APIVersion2,
+			Mirror:       true,
+			TrimHostname: true,
+			TLSConfig:    mirrorTLSConfig,
+		})
+	}
+	return endpoints, nil
+}
@@ -33,6 +33,7 @@ type ContainerOptions struct {
 	flLinks             opts.ListOpts
 	flAliases           opts.ListOpts
 	flLinkLocalIPs      opts.ListOpts
+	flNetworkRate       opts.ListOpts
 	flDeviceReadIOps    ThrottledeviceOpt
 	flDeviceWriteIOps   ThrottledeviceOpt
 	flEnv               opts.ListOpts
@@ -57,6 +58,7 @@ type ContainerOptions struct {
 	flLoggingOpts       opts.ListOpts
 	flPrivileged        bool
 	flPidMode           string
+	flPod               string
 	flUTSMode           string
 	flUsernsMode        string
 	flPublishAll        bool
@@ -132,6 +134,7 @@ func AddFlags(flags *pflag.FlagSet) *ContainerOptions {
 		flLabels:            opts.NewListOpts(ValidateEnv),
 		flLabelsFile:        opts.NewListOpts(nil),
 		flLinkLocalIPs:      opts.NewListOpts(nil),
+		flNetworkRate:       opts.NewListOpts(nil),
 		flLinks:             opts.NewListOpts(ValidateLink),
 		flLoggingOpts:       opts.NewListOpts(nil),
 		flPublish:           opts.NewListOpts(nil),
@@ -231,6 +234,7 @@ func AddFlags(flags *pflag.FlagSet) *ContainerOptions {
 	flags.StringVar(&copts.flMemorySwap, "memory-swap", "", "Swap limit equal to memory plus swap: '-1' to enable unlimited swap")
 	flags.Int64Var(&copts.flSwappiness, "memory-swappiness", -1, "Tune container memory swappiness (0 to 100)")
 	flags.BoolVar(&copts.flOomKillDisable, "oom-kill-disable", false, "Disable OOM Killer")
+	flags.Var(&copts.flNetworkRate, "network-rate", "Limit the network rate (bytes per second), as ingress=<rate>, egress=<rate> or <rate> for both")
 	flags.IntVar(&copts.flOomScoreAdj, "oom-score-adj", 0, "Tune host's OOM preferences (-1000 to 1000)")
 	flags.Int64Var(&copts.flPidsLimit, "pids-limit", 0, "Tune container pids limit (set -1 for unlimited)")
 
@@ -239,6 +243,7 @@ func AddFlags(flags *pflag.FlagSet) *ContainerOptions {
 	flags.StringVar(&copts.flIpcMode, "ipc", "", "IPC namespace to use")
 	flags.StringVar(&copts.flIsolation, "isolation", "", "Container isolation technology")
 	flags.StringVar(&copts.flPidMode, "pid", "", "PID namespace to use")
+	flags.StringVar(&copts.flPod, "pod", "", "Pod to join the namespaces of")
 	flags.StringVar(&copts.flShmSize, "shm-size", "", "Size of /dev/shm, default value is 64MB")
 	flags.StringVar(&copts.flUTSMode, "uts", "", "UTS namespace to use")
 	flags.StringVar(&copts.flRuntime, "runtime", "", "Runtime to use for this container")
@@ -333,6 +338,11 @@ func Parse(flags *pflag.FlagSet, copts *ContainerOptions) (*container.Config, *c
 		}
 	}
 
+	networkIngressRate, networkEgressRate, err := ParseNetworkRate(copts.flNetworkRate.GetAll())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
 	var binds []string
 	// add any bind targets to the list of container volumes
 	for bind := range copts.flVolumes.GetMap() {
@@ -507,6 +517,8 @@ func Parse(flags *pflag.FlagSet, copts *ContainerOptions) (*container.Config, *c
 		CpusetMems:           copts.flCpusetMems,
 		CPUQuota:             copts.flCPUQuota,
 		PidsLimit:            copts.flPidsLimit,
+		NetworkIngressRate:   networkIngressRate,
+		NetworkEgressRate:    networkEgressRate,
 		BlkioWeight:          copts.flBlkioWeight,
 		BlkioWeightDevice:    copts.flBlkioWeightDevice.GetList(),
 		BlkioDeviceReadBps:   copts.flDeviceReadBps.GetList(),
@@ -567,6 +579,7 @@ func Parse(flags *pflag.FlagSet, copts *ContainerOptions) (*container.Config, *c
 		NetworkMode:    container.NetworkMode(copts.flNetMode),
 		IpcMode:        ipcMode,
 		PidMode:        pidMode,
+		Pod:            copts.flPod,
 		UTSMode:        utsMode,
 		UsernsMode:     usernsMode,
 		CapAdd:         strslice.StrSlice(copts.flCapAdd.GetAll()),
@@ -754,6 +767,40 @@ func ParseRestartPolicy(policy string) (container.RestartPolicy, error) {
 	return p, nil
 }
 
+// ParseNetworkRate parses network rate limits, in the form ingress=<rate>,
+// egress=<rate>, or <rate> for both directions. Rates are in bytes per
+// second, with an optional unit (e.g. 10mb), and -1 removes a limit.
+func ParseNetworkRate(rates []string) (ingress int64, egress int64, err error) {
+	for _, r := range rates {
+		direction, value := "", r
+		if arr := strings.SplitN(r, "=", 2); len(arr) == 2 {
+			direction, value = arr[0], arr[1]
+		}
+
+		var rate int64 = -1
+		if value != "-1" {
+			if rate, err = units.RAMInBytes(value); err != nil {
+				return 0, 0, fmt.Errorf("invalid network rate %s: %v", r, err)
+			}
+			if rate <= 0 {
+				return 0, 0, fmt.Errorf("invalid network rate %s: the rate must be positive", r)
+			}
+		}
+
+		switch direction {
+		case "":
+			ingress, egress = rate, rate
+		case "ingress":
+			ingress = rate
+		case "egress":
+			egress = rate
+		default:
+			return 0, 0, fmt.Errorf("invalid network rate %s: unknown direction %s", r, direction)
+		}
+	}
+	return ingress, egress, nil
+}
+
 // ParseDevice parses a device mapping string to a container.DeviceMapping struct
 func ParseDevice(device string) (container.DeviceMapping, error) {
 	src := ""
@@ -554,6 +554,43 @@ func TestParseModes(t *testing.T) {
 	}
 }
 
+func TestParseNetworkRate(t *testing.T) {
+	invalids := map[string]string{
+		"fast":          "invalid network rate fast: invalid size: 'fast'",
+		"ingress=0":     "invalid network rate ingress=0: the rate must be positive",
+		"sideways=10mb": "invalid network rate sideways=10mb: unknown direction sideways",
+	}
+	for rate, expectedError := range invalids {
+		if _, _, _, err := parseRun([]string{"--network-rate", rate, "img", "cmd"}); err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected an error with message '%v' for %v, got %v", expectedError, rate, err)
+		}
+	}
+
+	valids := []struct {
+		rates           []string
+		ingress, egress int64
+	}{
+		{nil, 0, 0},
+		{[]string{"1mb"}, 1024 * 1024, 1024 * 1024},
+		{[]string{"ingress=10kb"}, 10 * 1024, 0},
+		{[]string{"egress=512"}, 0, 512},
+		{[]string{"1mb", "egress=-1"}, 1024 * 1024, -1},
+	}
+	for _, v := range valids {
+		args := []string{}
+		for _, r := range v.rates {
+			args = append(args, "--network-rate", r)
+		}
+		_, hostconfig, _, err := parseRun(append(args, "img", "cmd"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hostconfig.NetworkIngressRate != v.ingress || hostconfig.NetworkEgressRate != v.egress {
+			t.Fatalf("Expected ingress %d and egress %d for %v, got %d and %d", v.ingress, v.egress, v.rates, hostconfig.NetworkIngressRate, hostconfig.NetworkEgressRate)
+		}
+	}
+}
+
 func TestParseRestartPolicy(t *testing.T) {
 	invalids := map[string]string{
 		"something":          "invalid restart policy something",
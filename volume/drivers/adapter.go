@@ -3,6 +3,7 @@ package volumedrivers
 import (
 	"errors"
 	"strings"
+	"sync"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/volume"
@@ -15,6 +16,7 @@ var (
 
 type volumeDriverAdapter struct {
 	name         string
+	mu           sync.Mutex
 	capabilities *volume.Capability
 	proxy        *volumeDriverProxy
 }
@@ -82,16 +84,28 @@ func (a *volumeDriverAdapter) Scope() string {
 }
 
 func (a *volumeDriverAdapter) getCapabilities() volume.Capability {
-	if a.capabilities != nil {
-		return *a.capabilities
-	}
-	cap, err := a.proxy.Capabilities()
+	cap, err := a.fetchCapabilities(a.proxy)
 	if err != nil {
 		// `GetCapabilities` is a not a required endpoint.
 		// On error assume it's a local-only driver
 		logrus.Warnf("Volume driver %s returned an error while trying to query its capabilities, using default capabilties: %v", a.name, err)
 		return volume.Capability{Scope: volume.LocalScope}
 	}
+	return cap
+}
+
+// fetchCapabilities returns the capabilities of the driver, querying them
+// with the given proxy until they are successfully retrieved.
+func (a *volumeDriverAdapter) fetchCapabilities(proxy *volumeDriverProxy) (volume.Capability, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.capabilities != nil {
+		return *a.capabilities, nil
+	}
+	cap, err := proxy.Capabilities()
+	if err != nil {
+		return cap, err
+	}
 
 	// don't spam the warn log below just because the plugin didn't provide a scope
 	if len(cap.Scope) == 0 {
@@ -105,7 +119,59 @@ func (a *volumeDriverAdapter) getCapabilities() volume.Capability {
 	}
 
 	a.capabilities = &cap
-	return cap
+	return cap, nil
+}
+
+// OptionsSchema returns the options schema of drivers implementing
+// version 2 of the plugin protocol, and nil for older drivers.
+func (a *volumeDriverAdapter) OptionsSchema() []volume.OptionSpec {
+	cap := a.getCapabilities()
+	if cap.Version < 2 {
+		return nil
+	}
+	return cap.Options
+}
+
+// noRetryCaller is implemented by plugin clients which can call a method
+// without retrying while the plugin can't be reached.
+type noRetryCaller interface {
+	CallNoRetry(string, interface{}, interface{}) error
+}
+
+// noRetryClient calls the methods of a plugin without retrying.
+type noRetryClient struct {
+	noRetryCaller
+}
+
+func (c noRetryClient) Call(serviceMethod string, args, ret interface{}) error {
+	return c.CallNoRetry(serviceMethod, args, ret)
+}
+
+// Health checks the health of drivers implementing version 2 of the plugin
+// protocol, and returns volume.ErrNotSupported for older drivers. The check
+// is not retried, an unreachable plugin is reported right away as unhealthy.
+func (a *volumeDriverAdapter) Health() error {
+	proxy := a.proxy
+	if c, ok := a.proxy.client.(noRetryCaller); ok {
+		proxy = &volumeDriverProxy{noRetryClient{c}}
+	}
+	cap, err := a.fetchCapabilities(proxy)
+	if err != nil {
+		return err
+	}
+	if cap.Version < 2 {
+		return volume.ErrNotSupported
+	}
+	return proxy.Health()
+}
+
+// Resize changes the size of a volume if the driver advertises the resize
+// capability, and returns volume.ErrNotSupported otherwise.
+func (a *volumeDriverAdapter) Resize(v volume.Volume, size int64) error {
+	if !a.getCapabilities().Resize {
+		return volume.ErrNotSupported
+	}
+	return a.proxy.Resize(v.Name(), size)
 }
 
 type volumeAdapter struct {
@@ -19,6 +19,9 @@ var drivers = &driverExtpoint{extensions: make(map[string]volume.Driver), driver
 const extName = "VolumeDriver"
 
 // NewVolumeDriver returns a driver has the given name mapped on the given client.
+// The capabilities of the driver are queried when first needed, and again
+// until they are successfully retrieved, to find out which version of the
+// protocol it implements.
 func NewVolumeDriver(name string, c client) volume.Driver {
 	proxy := &volumeDriverProxy{c}
 	return &volumeDriverAdapter{name: name, proxy: proxy}
@@ -44,6 +47,10 @@ type volumeDriver interface {
 	Get(name string) (volume *proxyVolume, err error)
 	// Capabilities gets the list of capabilities of the driver
 	Capabilities() (capabilities volume.Capability, err error)
+	// Health checks whether the driver is able to serve requests
+	Health() (err error)
+	// Resize sets the size of the given volume
+	Resize(name string, size int64) (err error)
 }
 
 type driverExtpoint struct {
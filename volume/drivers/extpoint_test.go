@@ -1,9 +1,17 @@
 package volumedrivers
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
+	"github.com/docker/docker/pkg/plugins"
+	"github.com/docker/docker/volume"
 	"github.com/docker/docker/volume/testutils"
+	"github.com/docker/go-connections/tlsconfig"
 )
 
 func TestGetDriver(t *testing.T) {
@@ -21,3 +29,71 @@ func TestGetDriver(t *testing.T) {
 		t.Fatalf("Expected fake driver, got %s\n", d.Name())
 	}
 }
+
+func TestNewVolumeDriverVersion(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	var caps string
+	mux.HandleFunc("/VolumeDriver.Capabilities", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.docker.plugins.v1+json")
+		if caps == "" {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintln(w, `{"Err": "not ready"}`)
+			return
+		}
+		fmt.Fprintln(w, caps)
+	})
+	mux.HandleFunc("/VolumeDriver.Health", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.docker.plugins.v1+json")
+		fmt.Fprintln(w, `{}`)
+	})
+
+	u, _ := url.Parse(server.URL)
+	client, err := plugins.NewClient("tcp://"+u.Host, &tlsconfig.Options{InsecureSkipVerify: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	caps = `{"Capabilities": {"Scope": "global"}}`
+	d := NewVolumeDriver("v1", client)
+	if err := d.(volume.HealthChecker).Health(); err != volume.ErrNotSupported {
+		t.Fatalf("Expected a version 1 driver not to report its health, got %v", err)
+	}
+	if schema := d.(volume.OptionsDescriber).OptionsSchema(); schema != nil {
+		t.Fatalf("Expected a version 1 driver not to describe its options, got %v", schema)
+	}
+
+	// the capabilities are queried again until they are retrieved
+	caps = ""
+	d = NewVolumeDriver("v2", client)
+	if schema := d.(volume.OptionsDescriber).OptionsSchema(); schema != nil {
+		t.Fatalf("Expected no options schema without capabilities, got %v", schema)
+	}
+	caps = `{"Capabilities": {"Scope": "global", "Version": 2, "Options": [{"Name": "replicas", "Type": "int"}]}}`
+	if d.Scope() != volume.GlobalScope {
+		t.Fatalf("Expected global scope, got %s", d.Scope())
+	}
+	if err := d.(volume.HealthChecker).Health(); err != nil {
+		t.Fatalf("Expected driver to be healthy, got %v", err)
+	}
+	schema := d.(volume.OptionsDescriber).OptionsSchema()
+	if len(schema) != 1 || schema[0].Name != "replicas" || schema[0].Type != volume.OptionTypeInt {
+		t.Fatalf("Unexpected options schema: %v", schema)
+	}
+	err = d.(volume.Resizer).Resize(volumetestutils.NewFakeVolume("foo", "v2"), 1024)
+	if err != volume.ErrNotSupported {
+		t.Fatalf("Expected resize to be refused, got %v", err)
+	}
+
+	// an unreachable plugin is reported as unhealthy without retrying
+	server.Close()
+	start := time.Now()
+	if err := d.(volume.HealthChecker).Health(); err == nil {
+		t.Fatal("Expected an unreachable driver to be unhealthy")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("Expected the health check not to be retried, took %v", elapsed)
+	}
+}
@@ -239,3 +239,55 @@ func (pp *volumeDriverProxy) Capabilities() (capabilities volume.Capability, err
 
 	return
 }
+
+type volumeDriverProxyHealthRequest struct {
+}
+
+type volumeDriverProxyHealthResponse struct {
+	Err string
+}
+
+func (pp *volumeDriverProxy) Health() (err error) {
+	var (
+		req volumeDriverProxyHealthRequest
+		ret volumeDriverProxyHealthResponse
+	)
+
+	if err = pp.Call("VolumeDriver.Health", req, &ret); err != nil {
+		return
+	}
+
+	if ret.Err != "" {
+		err = errors.New(ret.Err)
+	}
+
+	return
+}
+
+type volumeDriverProxyResizeRequest struct {
+	Name string
+	Size int64
+}
+
+type volumeDriverProxyResizeResponse struct {
+	Err string
+}
+
+func (pp *volumeDriverProxy) Resize(name string, size int64) (err error) {
+	var (
+		req volumeDriverProxyResizeRequest
+		ret volumeDriverProxyResizeResponse
+	)
+
+	req.Name = name
+	req.Size = size
+	if err = pp.Call("VolumeDriver.Resize", req, &ret); err != nil {
+		return
+	}
+
+	if ret.Err != "" {
+		err = errors.New(ret.Err)
+	}
+
+	return
+}
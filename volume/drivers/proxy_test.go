@@ -57,6 +57,16 @@ func TestVolumeRequestError(t *testing.T) {
 		http.Error(w, "error", 500)
 	})
 
+	mux.HandleFunc("/VolumeDriver.Health", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.docker.plugins.v1+json")
+		fmt.Fprintln(w, `{"Err": "Backend unreachable"}`)
+	})
+
+	mux.HandleFunc("/VolumeDriver.Resize", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.docker.plugins.v1+json")
+		fmt.Fprintln(w, `{"Err": "Cannot resize volume"}`)
+	})
+
 	u, _ := url.Parse(server.URL)
 	client, err := plugins.NewClient("tcp://"+u.Host, &tlsconfig.Options{InsecureSkipVerify: true})
 	if err != nil {
@@ -129,4 +139,20 @@ func TestVolumeRequestError(t *testing.T) {
 	if err == nil {
 		t.Fatal(err)
 	}
+
+	err = driver.Health()
+	if err == nil {
+		t.Fatal("Expected error, was nil")
+	}
+	if !strings.Contains(err.Error(), "Backend unreachable") {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+
+	err = driver.Resize("volume", 1024)
+	if err == nil {
+		t.Fatal("Expected error, was nil")
+	}
+	if !strings.Contains(err.Error(), "Cannot resize volume") {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
 }
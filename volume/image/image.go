@@ -0,0 +1,311 @@
+// Package imagevolume provides a volume driver whose volumes hold the
+// root filesystem of an image, mounted read-only.
+package imagevolume
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/ioutils"
+	"github.com/docker/docker/pkg/mount"
+	"github.com/docker/docker/volume"
+)
+
+const (
+	// DriverName is the name of the image volume driver.
+	DriverName = "image"
+
+	volumesPathName  = "image-volumes"
+	metadataFileName = "metadata.json"
+	dataPathName     = "_data"
+	// mountNamePrefix prefixes the names of the layers mounted by image
+	// volumes in the layer store, so they do not collide with containers.
+	mountNamePrefix = "image-volume-"
+)
+
+// ErrNotFound is the typed error returned when the requested volume name can't be found
+var ErrNotFound = fmt.Errorf("volume not found")
+
+type validationError struct {
+	error
+}
+
+func (validationError) IsValidationError() bool {
+	return true
+}
+
+// ImageGetter returns the image with the given reference or ID.
+type ImageGetter func(refOrID string) (*image.Image, error)
+
+// Root implements the volume.Driver interface for volumes holding the
+// root filesystem of an image. The name of a volume is the reference or
+// ID of its image, which must be present on the host.
+type Root struct {
+	m        sync.Mutex
+	path     string
+	layers   layer.Store
+	getImage ImageGetter
+	volumes  map[string]*imageVolume
+}
+
+// metadata is the state of a volume persisted on disk.
+type metadata struct {
+	Name    string
+	ImageID image.ID
+	ChainID layer.ChainID
+}
+
+// New instantiates a new Root whose volumes are stored under the given
+// scope. The layers of the images are mounted from the layer store, and
+// image references are resolved with getImage.
+func New(scope string, layers layer.Store, getImage ImageGetter) (*Root, error) {
+	r := &Root{
+		path:     filepath.Join(scope, volumesPathName),
+		layers:   layers,
+		getImage: getImage,
+		volumes:  make(map[string]*imageVolume),
+	}
+	if err := os.MkdirAll(r.path, 0700); err != nil {
+		return nil, err
+	}
+
+	dirs, err := ioutil.ReadDir(r.path)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range dirs {
+		if !d.IsDir() {
+			continue
+		}
+		dir := filepath.Join(r.path, d.Name())
+		b, err := ioutil.ReadFile(filepath.Join(dir, metadataFileName))
+		if err != nil {
+			logrus.Errorf("Error reading image volume metadata in %s: %v", dir, err)
+			continue
+		}
+		var meta metadata
+		if err := json.Unmarshal(b, &meta); err != nil {
+			logrus.Errorf("Error reading image volume metadata in %s: %v", dir, err)
+			continue
+		}
+		rwLayer, err := layers.GetRWLayer(mountName(meta.Name))
+		if err != nil {
+			logrus.Errorf("Error restoring image volume %s: %v", meta.Name, err)
+			continue
+		}
+
+		v := &imageVolume{meta: meta, path: filepath.Join(dir, dataPathName), rwLayer: rwLayer}
+		// unmount anything that may still be mounted (for example, from an unclean shutdown)
+		if err := mount.Unmount(v.path); err != nil {
+			logrus.Warnf("Error unmounting image volume %s: %v", meta.Name, err)
+		}
+		r.volumes[meta.Name] = v
+	}
+	return r, nil
+}
+
+// Name returns the name of Root, defined in the DriverName constant.
+func (r *Root) Name() string {
+	return DriverName
+}
+
+// Create creates a volume holding the root filesystem of the image with
+// the given reference or ID.
+func (r *Root) Create(name string, opts map[string]string) (volume.Volume, error) {
+	if len(opts) > 0 {
+		return nil, validationError{fmt.Errorf("image volumes do not accept options")}
+	}
+
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	if v, exists := r.volumes[name]; exists {
+		return v, nil
+	}
+
+	img, err := r.getImage(name)
+	if err != nil {
+		return nil, validationError{fmt.Errorf("unable to create image volume %s: %v", name, err)}
+	}
+
+	dir := filepath.Join(r.path, nameHash(name))
+	v := &imageVolume{
+		meta: metadata{
+			Name:    name,
+			ImageID: img.ID(),
+			ChainID: img.RootFS.ChainID(),
+		},
+		path: filepath.Join(dir, dataPathName),
+	}
+	if err := os.MkdirAll(v.path, 0755); err != nil {
+		return nil, err
+	}
+
+	v.rwLayer, err = r.layers.CreateRWLayer(mountName(name), v.meta.ChainID, "", nil, nil)
+	if err == layer.ErrMountNameConflict {
+		// left over by a volume whose metadata was not saved
+		v.rwLayer, err = r.layers.GetRWLayer(mountName(name))
+	}
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	b, err := json.Marshal(v.meta)
+	if err == nil {
+		err = ioutils.AtomicWriteFile(filepath.Join(dir, metadataFileName), b, 0600)
+	}
+	if err != nil {
+		if _, releaseErr := r.layers.ReleaseRWLayer(v.rwLayer); releaseErr != nil {
+			logrus.Errorf("Error releasing layer of image volume %s: %v", name, releaseErr)
+		}
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	r.volumes[name] = v
+	return v, nil
+}
+
+// Remove removes the volume and releases the layers of its image. The
+// volume must not be mounted.
+func (r *Root) Remove(vol volume.Volume) error {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	v, ok := vol.(*imageVolume)
+	if !ok {
+		return fmt.Errorf("unknown volume type %T", vol)
+	}
+
+	v.m.Lock()
+	defer v.m.Unlock()
+	if v.mounts > 0 {
+		return fmt.Errorf("volume %s is mounted", v.meta.Name)
+	}
+	if _, err := r.layers.ReleaseRWLayer(v.rwLayer); err != nil {
+		return err
+	}
+	delete(r.volumes, v.meta.Name)
+	return os.RemoveAll(filepath.Dir(v.path))
+}
+
+// List lists all the volumes
+func (r *Root) List() ([]volume.Volume, error) {
+	var ls []volume.Volume
+	r.m.Lock()
+	for _, v := range r.volumes {
+		ls = append(ls, v)
+	}
+	r.m.Unlock()
+	return ls, nil
+}
+
+// Get looks up the volume for the given name and returns it if found
+func (r *Root) Get(name string) (volume.Volume, error) {
+	r.m.Lock()
+	v, exists := r.volumes[name]
+	r.m.Unlock()
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return v, nil
+}
+
+// Scope returns the local volume scope
+func (r *Root) Scope() string {
+	return volume.LocalScope
+}
+
+// imageVolume is a volume holding the root filesystem of an image. The
+// filesystem is mounted through a layer of the layer store, which is
+// bind mounted read-only on the volume path.
+type imageVolume struct {
+	m       sync.Mutex
+	meta    metadata
+	path    string
+	rwLayer layer.RWLayer
+	// mounts is the number of active mounts of the volume
+	mounts int
+}
+
+// Name returns the name of the volume
+func (v *imageVolume) Name() string {
+	return v.meta.Name
+}
+
+// DriverName returns the name of the driver of the volume
+func (v *imageVolume) DriverName() string {
+	return DriverName
+}
+
+// Path returns the data location of the volume
+func (v *imageVolume) Path() string {
+	return v.path
+}
+
+// Mount mounts the root filesystem of the image read-only on the volume
+// path, and returns the path.
+func (v *imageVolume) Mount(id string) (string, error) {
+	v.m.Lock()
+	defer v.m.Unlock()
+	if v.mounts == 0 {
+		rootfs, err := v.rwLayer.Mount("")
+		if err != nil {
+			return "", err
+		}
+		if err := mount.Mount(rootfs, v.path, "bind", "rbind,ro"); err != nil {
+			v.rwLayer.Unmount()
+			return "", err
+		}
+	}
+	v.mounts++
+	return v.path, nil
+}
+
+// Unmount releases a mount of the volume, and unmounts the root
+// filesystem of the image when it is no longer used.
+func (v *imageVolume) Unmount(id string) error {
+	v.m.Lock()
+	defer v.m.Unlock()
+	if v.mounts == 0 {
+		return fmt.Errorf("volume %s is not mounted", v.meta.Name)
+	}
+	if v.mounts == 1 {
+		if err := mount.Unmount(v.path); err != nil {
+			return err
+		}
+		if err := v.rwLayer.Unmount(); err != nil {
+			return err
+		}
+	}
+	v.mounts--
+	return nil
+}
+
+// Status returns the ID of the image held by the volume.
+func (v *imageVolume) Status() map[string]interface{} {
+	return map[string]interface{}{"Image": v.meta.ImageID.String()}
+}
+
+// nameHash returns a name suitable for a path for the volume name, which
+// is an image reference that may contain slashes and colons.
+func nameHash(name string) string {
+	h := sha256.Sum256([]byte(name))
+	return hex.EncodeToString(h[:])
+}
+
+// mountName returns the name of the layer mounted for the volume in the
+// layer store.
+func mountName(name string) string {
+	return mountNamePrefix + nameHash(name)
+}
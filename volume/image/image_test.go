@@ -0,0 +1,190 @@
+package imagevolume
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/mount"
+)
+
+// fakeLayerStore creates layers holding a single file, in place of the
+// layers of an image.
+type fakeLayerStore struct {
+	layer.Store
+	root   string
+	layers map[string]*fakeRWLayer
+}
+
+func (s *fakeLayerStore) CreateRWLayer(name string, parent layer.ChainID, mountLabel string, initFunc layer.MountInit, storageOpt map[string]string) (layer.RWLayer, error) {
+	if _, exists := s.layers[name]; exists {
+		return nil, layer.ErrMountNameConflict
+	}
+	path := filepath.Join(s.root, name)
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(path, "file"), []byte("image data"), 0644); err != nil {
+		return nil, err
+	}
+	l := &fakeRWLayer{name: name, path: path}
+	s.layers[name] = l
+	return l, nil
+}
+
+func (s *fakeLayerStore) GetRWLayer(name string) (layer.RWLayer, error) {
+	l, exists := s.layers[name]
+	if !exists {
+		return nil, layer.ErrMountDoesNotExist
+	}
+	return l, nil
+}
+
+func (s *fakeLayerStore) ReleaseRWLayer(l layer.RWLayer) ([]layer.Metadata, error) {
+	delete(s.layers, l.Name())
+	return nil, nil
+}
+
+type fakeRWLayer struct {
+	layer.RWLayer
+	name   string
+	path   string
+	mounts int
+}
+
+func (l *fakeRWLayer) Name() string {
+	return l.name
+}
+
+func (l *fakeRWLayer) Mount(mountLabel string) (string, error) {
+	l.mounts++
+	return l.path, nil
+}
+
+func (l *fakeRWLayer) Unmount() error {
+	l.mounts--
+	return nil
+}
+
+func getImage(refOrID string) (*image.Image, error) {
+	if refOrID != "busybox:latest" {
+		return nil, fmt.Errorf("No such image: %s", refOrID)
+	}
+	return &image.Image{RootFS: image.NewRootFS()}, nil
+}
+
+func TestCreate(t *testing.T) {
+	rootDir, err := ioutil.TempDir("", "image-volume-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+
+	layers := &fakeLayerStore{root: rootDir, layers: make(map[string]*fakeRWLayer)}
+	r, err := New(rootDir, layers, getImage)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := r.Create("notanimage", nil); err == nil {
+		t.Fatal("expected error creating a volume for a missing image")
+	}
+	if _, err := r.Create("busybox:latest", map[string]string{"size": "10m"}); err == nil {
+		t.Fatal("expected error creating a volume with options")
+	}
+	if _, err := r.Get("notanimage"); err != ErrNotFound {
+		t.Fatalf("expected %v, got %v", ErrNotFound, err)
+	}
+
+	v, err := r.Create("busybox:latest", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Name() != "busybox:latest" || v.DriverName() != DriverName {
+		t.Fatalf("unexpected volume %s with driver %s", v.Name(), v.DriverName())
+	}
+	if len(layers.layers) != 1 {
+		t.Fatalf("expected 1 layer, got %d", len(layers.layers))
+	}
+
+	r, err = New(rootDir, layers, getImage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err = r.Get("busybox:latest")
+	if err != nil {
+		t.Fatalf("missing volume on restart: %v", err)
+	}
+
+	if err := r.Remove(v); err != nil {
+		t.Fatal(err)
+	}
+	if len(layers.layers) != 0 {
+		t.Fatalf("expected the layer to be released, got %d layers", len(layers.layers))
+	}
+	if _, err := os.Stat(filepath.Dir(v.Path())); !os.IsNotExist(err) {
+		t.Fatalf("expected the volume directory to be removed: %v", err)
+	}
+}
+
+func TestMountReadOnly(t *testing.T) {
+	if runtime.GOOS != "linux" || os.Getuid() != 0 {
+		t.Skip("mounting requires root on linux")
+	}
+
+	rootDir, err := ioutil.TempDir("", "image-volume-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+
+	layers := &fakeLayerStore{root: rootDir, layers: make(map[string]*fakeRWLayer)}
+	r, err := New(rootDir, layers, getImage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := r.Create("busybox:latest", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []string{"1", "2"} {
+		if _, err := v.Mount(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rw := layers.layers[mountName("busybox:latest")]
+	if rw.mounts != 1 {
+		t.Fatalf("expected the layer to be mounted once, got %d", rw.mounts)
+	}
+
+	if b, err := ioutil.ReadFile(filepath.Join(v.Path(), "file")); err != nil || string(b) != "image data" {
+		t.Fatalf("expected to read the image data, got %q: %v", b, err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(v.Path(), "file"), []byte("changed"), 0644); err == nil {
+		t.Fatal("expected the volume to be read-only")
+	}
+	if err := r.Remove(v); err == nil {
+		t.Fatal("expected error removing a mounted volume")
+	}
+
+	for _, id := range []string{"1", "2"} {
+		if err := v.Unmount(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if rw.mounts != 0 {
+		t.Fatalf("expected the layer to be unmounted, got %d mounts", rw.mounts)
+	}
+	if mounted, err := mount.Mounted(v.Path()); err != nil || mounted {
+		t.Fatalf("expected the volume path to be unmounted: %v", err)
+	}
+	if err := v.Unmount("3"); err == nil {
+		t.Fatal("expected error unmounting a volume that is not mounted")
+	}
+}
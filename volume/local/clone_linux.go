@@ -0,0 +1,115 @@
+// +build linux
+
+package local
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/docker/docker/pkg/pools"
+	"github.com/docker/docker/pkg/system"
+)
+
+// ficlone is the FICLONE ioctl from linux/fs.h.
+const ficlone = 0x40049409
+
+// copyData copies the tree at srcDir into the existing directory dstDir,
+// preserving ownership, permissions and timestamps.
+func copyData(srcDir, dstDir string) error {
+	return filepath.Walk(srcDir, func(srcPath string, f os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(srcDir, srcPath)
+		if err != nil {
+			return err
+		}
+		dstPath := filepath.Join(dstDir, relPath)
+
+		stat, ok := f.Sys().(*syscall.Stat_t)
+		if !ok {
+			return fmt.Errorf("Unable to get raw syscall.Stat_t data for %s", srcPath)
+		}
+
+		switch f.Mode() & os.ModeType {
+		case 0: // Regular file
+			if err := copyRegular(srcPath, dstPath, f.Mode(), stat); err != nil {
+				return err
+			}
+		case os.ModeDir:
+			if err := os.Mkdir(dstPath, f.Mode()); err != nil && !os.IsExist(err) {
+				return err
+			}
+		case os.ModeSymlink:
+			link, err := os.Readlink(srcPath)
+			if err != nil {
+				return err
+			}
+			if err := os.Symlink(link, dstPath); err != nil {
+				return err
+			}
+		case os.ModeNamedPipe, os.ModeSocket:
+			if err := syscall.Mkfifo(dstPath, stat.Mode); err != nil {
+				return err
+			}
+		case os.ModeDevice, os.ModeDevice | os.ModeCharDevice:
+			if err := syscall.Mknod(dstPath, stat.Mode, int(stat.Rdev)); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("Unknown file type for %s", srcPath)
+		}
+
+		if err := os.Lchown(dstPath, int(stat.Uid), int(stat.Gid)); err != nil {
+			return err
+		}
+
+		// There is no Lchmod, so the mode and times of symlinks are set
+		// without following them.
+		if f.Mode()&os.ModeSymlink != 0 {
+			return system.LUtimesNano(dstPath, []syscall.Timespec{stat.Atim, stat.Mtim})
+		}
+		if err := os.Chmod(dstPath, f.Mode()); err != nil {
+			return err
+		}
+		aTime := time.Unix(int64(stat.Atim.Sec), int64(stat.Atim.Nsec))
+		mTime := time.Unix(int64(stat.Mtim.Sec), int64(stat.Mtim.Nsec))
+		return system.Chtimes(dstPath, aTime, mTime)
+	})
+}
+
+// copyRegular clones srcPath to dstPath with a reflink, and falls back to
+// copying the content if the filesystem does not support it. The source file
+// must still be the file described by stat, it is not followed if it was
+// replaced by a symlink.
+func copyRegular(srcPath, dstPath string, mode os.FileMode, stat *syscall.Stat_t) error {
+	srcFile, err := os.OpenFile(srcPath, os.O_RDONLY|syscall.O_NOFOLLOW, 0)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+
+	var st syscall.Stat_t
+	if err := syscall.Fstat(int(srcFile.Fd()), &st); err != nil {
+		return err
+	}
+	if st.Dev != stat.Dev || st.Ino != stat.Ino {
+		return fmt.Errorf("%s changed while it was copied", srcPath)
+	}
+
+	dstFile, err := os.OpenFile(dstPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode)
+	if err != nil {
+		return err
+	}
+	defer dstFile.Close()
+
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, dstFile.Fd(), ficlone, srcFile.Fd()); errno == 0 {
+		return nil
+	}
+	_, err = pools.Copy(dstFile, srcFile)
+	return err
+}
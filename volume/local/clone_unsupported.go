@@ -0,0 +1,10 @@
+// +build !linux
+
+package local
+
+import "github.com/docker/docker/pkg/chrootarchive"
+
+// copyData copies the tree at srcDir into the existing directory dstDir.
+func copyData(srcDir, dstDir string) error {
+	return chrootarchive.CopyWithTar(srcDir, dstDir)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/pkg/idtools"
 	"github.com/docker/docker/pkg/mount"
+	"github.com/docker/docker/pkg/stringid"
 	"github.com/docker/docker/utils"
 	"github.com/docker/docker/volume"
 )
@@ -65,6 +66,7 @@ func New(scope string, rootUID, rootGID int) (*Root, error) {
 		volumes: make(map[string]*localVolume),
 		rootUID: rootUID,
 		rootGID: rootGID,
+		quota:   newQuotaControl(rootDirectory, rootUID, rootGID),
 	}
 
 	dirs, err := ioutil.ReadDir(rootDirectory)
@@ -87,6 +89,7 @@ func New(scope string, rootUID, rootGID int) (*Root, error) {
 			driverName: r.Name(),
 			name:       name,
 			path:       r.DataPath(name),
+			quota:      r.quota,
 		}
 		r.volumes[name] = v
 		optsFilePath := filepath.Join(rootDirectory, name, "opts.json")
@@ -97,6 +100,7 @@ func New(scope string, rootUID, rootGID int) (*Root, error) {
 			}
 			if !reflect.DeepEqual(opts, optsConfig{}) {
 				v.opts = &opts
+				r.quota.load(v)
 			}
 
 			// unmount anything that may still be mounted (for example, from an unclean shutdown)
@@ -122,6 +126,7 @@ type Root struct {
 	volumes map[string]*localVolume
 	rootUID int
 	rootGID int
+	quota   *quotaControl
 }
 
 // List lists all the volumes
@@ -180,18 +185,17 @@ func (r *Root) Create(name string, opts map[string]string) (volume.Volume, error
 		driverName: r.Name(),
 		name:       name,
 		path:       path,
+		quota:      r.quota,
 	}
 
 	if len(opts) != 0 {
 		if err = setOpts(v, opts); err != nil {
 			return nil, err
 		}
-		var b []byte
-		b, err = json.Marshal(v.opts)
-		if err != nil {
+		if err = r.quota.apply(v); err != nil {
 			return nil, err
 		}
-		if err = ioutil.WriteFile(filepath.Join(filepath.Dir(path), "opts.json"), b, 600); err != nil {
+		if err = v.saveOpts(); err != nil {
 			return nil, err
 		}
 	}
@@ -259,6 +263,56 @@ func (r *Root) Scope() string {
 	return volume.LocalScope
 }
 
+// Clone copies the data of src into dst. Where the filesystem supports
+// it, files are cloned with copy-on-write reflinks rather than copied.
+func (r *Root) Clone(src, dst volume.Volume) error {
+	srcVol, err := r.getLocal(src)
+	if err != nil {
+		return err
+	}
+	dstVol, err := r.getLocal(dst)
+	if err != nil {
+		return err
+	}
+
+	id := stringid.GenerateNonCryptoID()
+	srcPath, err := srcVol.Mount(id)
+	if err != nil {
+		return err
+	}
+	defer srcVol.Unmount(id)
+	dstPath, err := dstVol.Mount(id)
+	if err != nil {
+		return err
+	}
+	defer dstVol.Unmount(id)
+	return copyData(srcPath, dstPath)
+}
+
+// Resize changes the size limit of a volume created with the size option.
+func (r *Root) Resize(vol volume.Volume, size int64) error {
+	v, err := r.getLocal(vol)
+	if err != nil {
+		return err
+	}
+	if err := r.quota.resize(v, size); err != nil {
+		return err
+	}
+	return v.saveOpts()
+}
+
+// getLocal returns the volume of this driver with the name of the given,
+// possibly wrapped, volume.
+func (r *Root) getLocal(v volume.Volume) (*localVolume, error) {
+	r.m.Lock()
+	lv, exists := r.volumes[v.Name()]
+	r.m.Unlock()
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return lv, nil
+}
+
 func (r *Root) validateName(name string) error {
 	if !volumeNameRegex.MatchString(name) {
 		return validationError{fmt.Errorf("%q includes invalid characters for a local volume name, only %q are allowed", name, utils.RestrictedNameChars)}
@@ -266,6 +320,15 @@ func (r *Root) validateName(name string) error {
 	return nil
 }
 
+// saveOpts persists the options of the volume next to its data.
+func (v *localVolume) saveOpts() error {
+	b, err := json.Marshal(v.opts)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(filepath.Dir(v.path), "opts.json"), b, 600)
+}
+
 // localVolume implements the Volume interface from the volume package and
 // represents the volumes created by Root.
 type localVolume struct {
@@ -280,6 +343,8 @@ type localVolume struct {
 	opts *optsConfig
 	// active refcounts the active mounts
 	active activeMount
+	// quota enforces and reports the size limit set in opts
+	quota *quotaControl
 }
 
 // Name returns the name of the given Volume.
@@ -339,6 +404,8 @@ func validateOpts(opts map[string]string) error {
 	return nil
 }
 
+// Status returns the size limit and current usage of the volume, if it
+// was created with a size option.
 func (v *localVolume) Status() map[string]interface{} {
-	return nil
+	return v.status()
 }
@@ -10,8 +10,13 @@ import (
 	"testing"
 
 	"github.com/docker/docker/pkg/mount"
+	"github.com/docker/docker/pkg/reexec"
 )
 
+func init() {
+	reexec.Init()
+}
+
 func TestRemove(t *testing.T) {
 	// TODO Windows: Investigate why this test fails on Windows under CI
 	//               but passes locally.
@@ -269,6 +274,176 @@ func TestCreateWithOpts(t *testing.T) {
 	}
 }
 
+func TestCreateWithSizeOpts(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip()
+	}
+
+	rootDir, err := ioutil.TempDir("", "local-volume-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+
+	r, err := New(rootDir, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, opts := range []map[string]string{
+		{"size": "notasize"},
+		{"size": "0"},
+		{"size": "10m", "device": "/dev/sdb1", "type": "ext4"},
+	} {
+		if _, err := r.Create("test", opts); err == nil {
+			t.Fatalf("expected %v to cause error", opts)
+		}
+	}
+
+	vol, err := r.Create("test", map[string]string{"size": "16m"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := vol.(*localVolume)
+	if v.opts.Size != 16*1024*1024 {
+		t.Fatalf("expected size to be %d, got %d", 16*1024*1024, v.opts.Size)
+	}
+
+	if _, err := v.Mount("1234"); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := v.Unmount("1234"); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	status := v.Status()
+	if status["Size"] != v.opts.Size {
+		t.Fatalf("expected status to report size %d, got %v", v.opts.Size, status["Size"])
+	}
+	if _, ok := status["Used"]; !ok {
+		t.Fatalf("expected status to report usage: %v", status)
+	}
+
+	r, err = New(rootDir, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v2, exists := r.volumes["test"]
+	if !exists {
+		t.Fatal("missing volume on restart")
+	}
+	if !reflect.DeepEqual(v.opts, v2.opts) {
+		t.Fatal("missing volume options on restart")
+	}
+}
+
+func TestCreateTmpfs(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip()
+	}
+
+	rootDir, err := ioutil.TempDir("", "local-volume-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+
+	r, err := New(rootDir, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vol, err := r.Create("test", map[string]string{"type": "tmpfs", "o": "mode=1777", "size": "1m"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := vol.(*localVolume)
+	if v.opts.MountDevice != "tmpfs" {
+		t.Fatalf("expected device to default to tmpfs, got %q", v.opts.MountDevice)
+	}
+	if opts := v.opts.tmpfsOpts(); opts != "mode=1777,size=1048576" {
+		t.Fatalf("unexpected tmpfs options: %q", opts)
+	}
+
+	p, err := v.Mount("1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := v.Unmount("1234"); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	data := make([]byte, 2*1024*1024)
+	if err := ioutil.WriteFile(filepath.Join(p, "data"), data, 0644); err == nil {
+		t.Fatal("expected write beyond the size of the volume to fail")
+	}
+
+	if err := r.Resize(v, 4*1024*1024); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(p, "data"), data, 0644); err != nil {
+		t.Fatalf("expected write to succeed after resize: %v", err)
+	}
+}
+
+func TestClone(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip()
+	}
+
+	rootDir, err := ioutil.TempDir("", "local-volume-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+
+	r, err := New(rootDir, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := r.Create("src", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(src.Path(), "dir"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(src.Path(), "dir", "file"), []byte("hello"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	assertContent := func(v *localVolume) {
+		b, err := ioutil.ReadFile(filepath.Join(v.Path(), "dir", "file"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != "hello" {
+			t.Fatalf("expected file content to be %q, got %q", "hello", b)
+		}
+		fi, err := os.Stat(filepath.Join(v.Path(), "dir", "file"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fi.Mode().Perm() != 0600 {
+			t.Fatalf("expected file mode to be 0600, got %v", fi.Mode().Perm())
+		}
+	}
+
+	cloned, err := r.Create("cloned", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Clone(src, cloned); err != nil {
+		t.Fatal(err)
+	}
+	assertContent(cloned.(*localVolume))
+}
+
 func TestRealodNoOpts(t *testing.T) {
 	rootDir, err := ioutil.TempDir("", "volume-test-reload-no-opts")
 	if err != nil {
@@ -326,3 +501,28 @@ func TestRealodNoOpts(t *testing.T) {
 		}
 	}
 }
+
+func TestResizeWithoutSize(t *testing.T) {
+	rootDir, err := ioutil.TempDir("", "local-volume-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+
+	r, err := New(rootDir, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := r.Create("test", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.Resize(v, 1024*1024)
+	if err == nil {
+		t.Fatal("Expected resizing a volume without size option to fail")
+	}
+	if _, ok := err.(validationError); !ok {
+		t.Fatalf("Expected a validation error, got %v", err)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/docker/docker/pkg/mount"
+	"github.com/docker/go-units"
 )
 
 var (
@@ -20,6 +21,7 @@ var (
 		"type":   true, // specify the filesystem type for mount, e.g. nfs
 		"o":      true, // generic mount options
 		"device": true, // device to mount from
+		"size":   true, // maximum size of the volume data, e.g. 10G
 	}
 )
 
@@ -27,6 +29,11 @@ type optsConfig struct {
 	MountType   string
 	MountOpts   string
 	MountDevice string
+	// Size is the maximum size in bytes of the volume data.
+	Size int64
+	// ProjectID is the filesystem project quota ID that enforces Size.
+	// It is zero when Size is enforced by a loopback-mounted image.
+	ProjectID uint32
 }
 
 // scopedPath verifies that the path where the volume is located
@@ -58,10 +65,56 @@ func setOpts(v *localVolume, opts map[string]string) error {
 		MountOpts:   opts["o"],
 		MountDevice: opts["device"],
 	}
+
+	if v.opts.isTmpfs() && v.opts.MountDevice == "" {
+		v.opts.MountDevice = "tmpfs"
+	}
+
+	if val, ok := opts["size"]; ok {
+		if !v.opts.isTmpfs() && (v.opts.MountType != "" || v.opts.MountDevice != "" || v.opts.MountOpts != "") {
+			return validationError{fmt.Errorf("size option can only be combined with type=tmpfs")}
+		}
+		size, err := units.RAMInBytes(val)
+		if err != nil {
+			return validationError{fmt.Errorf("invalid size %q: %v", val, err)}
+		}
+		if size <= 0 {
+			return validationError{fmt.Errorf("invalid size %q: must be a positive value", val)}
+		}
+		v.opts.Size = size
+	}
 	return nil
 }
 
+// isTmpfs returns whether the volume data is kept in memory. The size of
+// a tmpfs volume is enforced by tmpfs itself.
+func (o *optsConfig) isTmpfs() bool {
+	return o.MountType == "tmpfs"
+}
+
+// tmpfsOpts returns the mount options of a tmpfs volume.
+func (o *optsConfig) tmpfsOpts() string {
+	if o.Size == 0 {
+		return o.MountOpts
+	}
+	size := fmt.Sprintf("size=%d", o.Size)
+	if o.MountOpts == "" {
+		return size
+	}
+	return o.MountOpts + "," + size
+}
+
 func (v *localVolume) mount() error {
+	if v.opts.isTmpfs() {
+		return mount.Mount(v.opts.MountDevice, v.path, "tmpfs", v.opts.tmpfsOpts())
+	}
+	if v.opts.Size > 0 {
+		if v.opts.ProjectID != 0 {
+			// the project quota applies to the data directory itself
+			return nil
+		}
+		return v.mountImage()
+	}
 	if v.opts.MountDevice == "" {
 		return fmt.Errorf("missing device in volume options")
 	}
@@ -32,3 +32,23 @@ func setOpts(v *localVolume, opts map[string]string) error {
 func (v *localVolume) mount() error {
 	return nil
 }
+
+func (v *localVolume) status() map[string]interface{} {
+	return nil
+}
+
+type quotaControl struct{}
+
+func newQuotaControl(path string, rootUID, rootGID int) *quotaControl {
+	return nil
+}
+
+func (q *quotaControl) load(v *localVolume) {}
+
+func (q *quotaControl) apply(v *localVolume) error {
+	return nil
+}
+
+func (q *quotaControl) resize(v *localVolume, size int64) error {
+	return validationError{fmt.Errorf("volume %s was not created with the size option", v.name)}
+}
@@ -0,0 +1,356 @@
+// +build linux
+
+package local
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/pkg/loopback"
+	"github.com/docker/docker/pkg/mount"
+)
+
+const (
+	// backingFsBlockDevName is the name of the block device node created
+	// in the volumes root to address the underlying filesystem in quotactl.
+	backingFsBlockDevName = "backingFsBlockDev"
+	// imageFileName is the name of the filesystem image backing a volume
+	// with a size limit when project quotas are not available.
+	imageFileName = "disk.img"
+
+	// ioctls and flags from linux/fs.h
+	fsIocFsGetXattr    = 0x801c581f
+	fsIocFsSetXattr    = 0x401c5820
+	fsXflagProjInherit = 0x200
+	// quotactl commands and flags from linux/quota.h and linux/dqblk_xfs.h
+	qXGetQuota     = 0x5803
+	qXSetQLim      = 0x5804
+	prjQuota       = 2
+	fsDquotVersion = 1
+	fsProjQuota    = 2
+	fsDqBSoft      = 1 << 2
+	fsDqBHard      = 1 << 3
+	// quota limits and usage are expressed in 512 byte basic blocks
+	basicBlockSize = 512
+)
+
+// fsxattr mirrors struct fsxattr from linux/fs.h.
+type fsxattr struct {
+	xflags     uint32
+	extsize    uint32
+	nextents   uint32
+	projid     uint32
+	cowextsize uint32
+	pad        [8]byte
+}
+
+// fsDiskQuota mirrors struct fs_disk_quota from linux/dqblk_xfs.h.
+type fsDiskQuota struct {
+	version      int8
+	flags        int8
+	fieldmask    uint16
+	id           uint32
+	blkHardlimit uint64
+	blkSoftlimit uint64
+	inoHardlimit uint64
+	inoSoftlimit uint64
+	bcount       uint64
+	icount       uint64
+	itimer       int32
+	btimer       int32
+	iwarns       uint16
+	bwarns       uint16
+	padding2     int32
+	rtbHardlimit uint64
+	rtbSoftlimit uint64
+	rtbcount     uint64
+	rtbtimer     int32
+	rtbwarns     uint16
+	padding3     int16
+	padding4     [8]byte
+}
+
+// quotaControl enforces the size option of local volumes. It uses
+// project quotas when the filesystem holding the volumes supports them
+// (xfs, or ext4 mounted with prjquota), and otherwise falls back to an
+// ext4 image file that is loopback-mounted on the volume data path.
+type quotaControl struct {
+	once              sync.Once
+	root              string
+	rootUID           int
+	rootGID           int
+	backingFsBlockDev string
+	supported         bool
+	// nextProjectID is the project ID assigned to the next sized volume.
+	nextProjectID uint32
+}
+
+func newQuotaControl(path string, rootUID, rootGID int) *quotaControl {
+	return &quotaControl{
+		root:          path,
+		rootUID:       rootUID,
+		rootGID:       rootGID,
+		nextProjectID: 1,
+	}
+}
+
+// load records the project ID of a volume restored from disk so that it
+// is not handed out again.
+func (q *quotaControl) load(v *localVolume) {
+	if v.opts.ProjectID >= q.nextProjectID {
+		q.nextProjectID = v.opts.ProjectID + 1
+	}
+}
+
+// apply sets up the size limit of a newly created volume.
+func (q *quotaControl) apply(v *localVolume) error {
+	if v.opts == nil || v.opts.Size == 0 || v.opts.isTmpfs() {
+		return nil
+	}
+
+	q.once.Do(q.probe)
+	if !q.supported {
+		return createImage(filepath.Join(filepath.Dir(v.path), imageFileName), v.opts.Size, q.rootUID, q.rootGID)
+	}
+
+	id := q.nextProjectID
+	if err := setProjectID(v.path, id); err != nil {
+		return err
+	}
+	if err := setProjectQuota(q.backingFsBlockDev, id, uint64(v.opts.Size)); err != nil {
+		return err
+	}
+	q.nextProjectID++
+	v.opts.ProjectID = id
+	return nil
+}
+
+// resize changes the size limit of a volume with a project quota.
+func (q *quotaControl) resize(v *localVolume, size int64) error {
+	if v.opts == nil || v.opts.Size == 0 {
+		return validationError{fmt.Errorf("volume %s was not created with the size option", v.name)}
+	}
+	if size <= 0 {
+		return validationError{fmt.Errorf("invalid size: %d", size)}
+	}
+	if v.opts.isTmpfs() {
+		return v.resizeTmpfs(size)
+	}
+	if v.opts.ProjectID == 0 {
+		return fmt.Errorf("volume %s is backed by a loopback image and cannot be resized", v.name)
+	}
+
+	q.once.Do(q.probe)
+	if q.backingFsBlockDev == "" {
+		return fmt.Errorf("project quotas are not available")
+	}
+	if err := setProjectQuota(q.backingFsBlockDev, v.opts.ProjectID, uint64(size)); err != nil {
+		return err
+	}
+	v.opts.Size = size
+	return nil
+}
+
+// probe checks whether project quotas can be set on the filesystem
+// holding the volumes root.
+func (q *quotaControl) probe() {
+	dev, err := makeBackingFsDev(q.root)
+	if err != nil {
+		logrus.Debugf("local volume: project quotas not available, using loopback images: %v", err)
+		return
+	}
+	q.backingFsBlockDev = dev
+
+	// keep clear of a project ID assigned to the volumes root itself
+	if base, err := getProjectID(q.root); err == nil && base >= q.nextProjectID {
+		q.nextProjectID = base + 1
+	}
+
+	// setting an empty limit is a no-op that fails without quota support
+	if err := setProjectQuota(dev, q.nextProjectID, 0); err != nil {
+		logrus.Debugf("local volume: project quotas not available, using loopback images: %v", err)
+		return
+	}
+	q.supported = true
+}
+
+// usage returns the number of bytes used by the volume data.
+func (q *quotaControl) usage(v *localVolume) (int64, error) {
+	if v.opts.ProjectID != 0 {
+		q.once.Do(q.probe)
+		if q.backingFsBlockDev == "" {
+			return 0, fmt.Errorf("project quotas are not available")
+		}
+		d, err := getProjectQuota(q.backingFsBlockDev, v.opts.ProjectID)
+		if err != nil {
+			return 0, err
+		}
+		return int64(d.bcount * basicBlockSize), nil
+	}
+
+	v.m.Lock()
+	defer v.m.Unlock()
+	if !v.active.mounted {
+		return 0, fmt.Errorf("volume is not mounted")
+	}
+	var buf syscall.Statfs_t
+	if err := syscall.Statfs(v.path, &buf); err != nil {
+		return 0, err
+	}
+	return int64(buf.Blocks-buf.Bfree) * buf.Bsize, nil
+}
+
+func (v *localVolume) status() map[string]interface{} {
+	if v.opts == nil || v.opts.Size == 0 {
+		return nil
+	}
+	status := map[string]interface{}{
+		"Size": v.opts.Size,
+	}
+	used, err := v.quota.usage(v)
+	if err != nil {
+		logrus.Debugf("local volume: unable to get usage of %s: %v", v.name, err)
+		return status
+	}
+	status["Used"] = used
+	return status
+}
+
+// resizeTmpfs changes the size of a tmpfs volume, remounting it if it is
+// mounted.
+func (v *localVolume) resizeTmpfs(size int64) error {
+	v.m.Lock()
+	defer v.m.Unlock()
+	if v.active.mounted {
+		if err := mount.Mount(v.opts.MountDevice, v.path, "tmpfs", fmt.Sprintf("remount,size=%d", size)); err != nil {
+			return err
+		}
+	}
+	v.opts.Size = size
+	return nil
+}
+
+// mountImage attaches the volume image to a loopback device and mounts
+// it on the volume data path.
+func (v *localVolume) mountImage() error {
+	loopFile, err := loopback.AttachLoopDevice(filepath.Join(filepath.Dir(v.path), imageFileName))
+	if err != nil {
+		return err
+	}
+	// the device is set to autoclear, so it is released once unmounted
+	defer loopFile.Close()
+	return mount.Mount(loopFile.Name(), v.path, "ext4", "")
+}
+
+// createImage creates a sparse ext4 image of the given size whose root
+// directory is owned by the remapped root.
+func createImage(path string, size int64, rootUID, rootGID int) error {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	err = f.Truncate(size)
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	args := []string{"-F", "-q", "-E", fmt.Sprintf("nodiscard,root_owner=%d:%d", rootUID, rootGID), path}
+	if out, err := exec.Command("mkfs.ext4", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to create volume image: %v (%s)", err, out)
+	}
+	return nil
+}
+
+// makeBackingFsDev creates a block device node in the given directory
+// for the filesystem the directory lives on.
+func makeBackingFsDev(dir string) (string, error) {
+	var stat syscall.Stat_t
+	if err := syscall.Stat(dir, &stat); err != nil {
+		return "", err
+	}
+
+	dev := filepath.Join(dir, backingFsBlockDevName)
+	if err := syscall.Unlink(dev); err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	if err := syscall.Mknod(dev, syscall.S_IFBLK|0600, int(stat.Dev)); err != nil {
+		return "", err
+	}
+	return dev, nil
+}
+
+func getProjectID(path string) (uint32, error) {
+	var attr fsxattr
+	if err := fsxattrIoctl(path, fsIocFsGetXattr, &attr); err != nil {
+		return 0, err
+	}
+	return attr.projid, nil
+}
+
+// setProjectID assigns the project ID to the directory and makes files
+// created beneath it inherit it.
+func setProjectID(path string, id uint32) error {
+	var attr fsxattr
+	if err := fsxattrIoctl(path, fsIocFsGetXattr, &attr); err != nil {
+		return err
+	}
+	attr.projid = id
+	attr.xflags |= fsXflagProjInherit
+	return fsxattrIoctl(path, fsIocFsSetXattr, &attr)
+}
+
+func fsxattrIoctl(path string, request uintptr, attr *fsxattr) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, dir.Fd(), request, uintptr(unsafe.Pointer(attr))); errno != 0 {
+		return fmt.Errorf("failed to access project ID of %s: %v", path, errno)
+	}
+	return nil
+}
+
+// setProjectQuota sets the block limit of the project, in bytes. A zero
+// size removes the limit.
+func setProjectQuota(backingFsBlockDev string, id uint32, size uint64) error {
+	d := fsDiskQuota{
+		version:      fsDquotVersion,
+		flags:        fsProjQuota,
+		fieldmask:    fsDqBSoft | fsDqBHard,
+		id:           id,
+		blkHardlimit: size / basicBlockSize,
+		blkSoftlimit: size / basicBlockSize,
+	}
+	if err := quotactl(qXSetQLim, backingFsBlockDev, id, &d); err != nil {
+		return fmt.Errorf("failed to set quota limit for project ID %d: %v", id, err)
+	}
+	return nil
+}
+
+func getProjectQuota(backingFsBlockDev string, id uint32) (*fsDiskQuota, error) {
+	var d fsDiskQuota
+	if err := quotactl(qXGetQuota, backingFsBlockDev, id, &d); err != nil {
+		return nil, fmt.Errorf("failed to get quota for project ID %d: %v", id, err)
+	}
+	return &d, nil
+}
+
+func quotactl(cmd int, special string, id uint32, d *fsDiskQuota) error {
+	p, err := syscall.BytePtrFromString(special)
+	if err != nil {
+		return err
+	}
+	if _, _, errno := syscall.Syscall6(syscall.SYS_QUOTACTL, uintptr(cmd<<8|prjQuota), uintptr(unsafe.Pointer(p)), uintptr(id), uintptr(unsafe.Pointer(d)), 0, 0); errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+// +build freebsd solaris
+
+package local
+
+import "fmt"
+
+type quotaControl struct{}
+
+func newQuotaControl(path string, rootUID, rootGID int) *quotaControl {
+	return nil
+}
+
+func (q *quotaControl) load(v *localVolume) {}
+
+func (q *quotaControl) apply(v *localVolume) error {
+	if v.opts != nil && v.opts.Size != 0 && !v.opts.isTmpfs() {
+		return validationError{fmt.Errorf("size option is not supported on this platform")}
+	}
+	return nil
+}
+
+func (q *quotaControl) resize(v *localVolume, size int64) error {
+	return validationError{fmt.Errorf("volume %s was not created with the size option", v.name)}
+}
+
+func (v *localVolume) status() map[string]interface{} {
+	return nil
+}
+
+func (v *localVolume) mountImage() error {
+	return fmt.Errorf("size option is not supported on this platform")
+}
@@ -0,0 +1,104 @@
+package volume
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/docker/go-units"
+)
+
+// Types of the values of volume driver options.
+const (
+	OptionTypeString = "string"
+	OptionTypeBool   = "bool"
+	OptionTypeInt    = "int"
+	OptionTypeSize   = "size"
+)
+
+// OptionSpec describes an option accepted by a volume driver on create.
+type OptionSpec struct {
+	// Name is the name of the option.
+	Name string
+	// Description is a short description of the option.
+	Description string
+	// Type is the type of the option value, `string` if empty.
+	Type string
+	// Required indicates that the option must be set.
+	Required bool
+	// Values restricts the option to the given values, if not empty.
+	Values []string
+}
+
+// optionError is returned when driver options do not match the schema of
+// the driver.
+type optionError struct {
+	error
+}
+
+func (optionError) IsValidationError() bool {
+	return true
+}
+
+// ValidateOpts checks that the options only contain options described by
+// the schema, with values of the expected type, and that all required
+// options are set.
+func ValidateOpts(schema []OptionSpec, opts map[string]string) error {
+	specs := make(map[string]OptionSpec, len(schema))
+	for _, spec := range schema {
+		specs[spec.Name] = spec
+	}
+
+	// sort the keys to report errors in a stable order
+	keys := make([]string, 0, len(opts))
+	for k := range opts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		spec, ok := specs[k]
+		if !ok {
+			return optionError{fmt.Errorf("invalid option key: %q", k)}
+		}
+		if err := validateOptValue(spec, opts[k]); err != nil {
+			return optionError{err}
+		}
+	}
+
+	for _, spec := range schema {
+		if _, ok := opts[spec.Name]; spec.Required && !ok {
+			return optionError{fmt.Errorf("missing required option: %q", spec.Name)}
+		}
+	}
+	return nil
+}
+
+func validateOptValue(spec OptionSpec, value string) error {
+	if len(spec.Values) > 0 {
+		for _, v := range spec.Values {
+			if v == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid value %q for option %q, expected one of: %s", value, spec.Name, strings.Join(spec.Values, ", "))
+	}
+
+	var err error
+	switch spec.Type {
+	case "", OptionTypeString:
+	case OptionTypeBool:
+		_, err = strconv.ParseBool(value)
+	case OptionTypeInt:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case OptionTypeSize:
+		_, err = units.RAMInBytes(value)
+	default:
+		return fmt.Errorf("option %q has an unknown type: %q", spec.Name, spec.Type)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid value %q for %s option %q", value, spec.Type, spec.Name)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package volume
+
+import "testing"
+
+func TestValidateOpts(t *testing.T) {
+	schema := []OptionSpec{
+		{Name: "type", Values: []string{"ssd", "hdd"}},
+		{Name: "replicas", Type: OptionTypeInt, Required: true},
+		{Name: "encrypted", Type: OptionTypeBool},
+		{Name: "size", Type: OptionTypeSize},
+		{Name: "comment"},
+	}
+
+	valid := []map[string]string{
+		{"replicas": "3"},
+		{"replicas": "3", "type": "ssd", "encrypted": "true", "size": "10g", "comment": "anything"},
+	}
+	for _, opts := range valid {
+		if err := ValidateOpts(schema, opts); err != nil {
+			t.Fatalf("Expected options %v to be valid, got %v", opts, err)
+		}
+	}
+
+	invalid := map[string]map[string]string{
+		`missing required option: "replicas"`:                               {},
+		`invalid option key: "unknown"`:                                     {"replicas": "3", "unknown": "1"},
+		`invalid value "tape" for option "type", expected one of: ssd, hdd`: {"replicas": "3", "type": "tape"},
+		`invalid value "three" for int option "replicas"`:                   {"replicas": "three"},
+		`invalid value "maybe" for bool option "encrypted"`:                 {"replicas": "3", "encrypted": "maybe"},
+		`invalid value "big" for size option "size"`:                        {"replicas": "3", "size": "big"},
+	}
+	for expected, opts := range invalid {
+		err := ValidateOpts(schema, opts)
+		if err == nil {
+			t.Fatalf("Expected options %v to be invalid", opts)
+		}
+		if err.Error() != expected {
+			t.Fatalf("Expected error %q, got %q", expected, err)
+		}
+		if _, ok := err.(optionError); !ok {
+			t.Fatalf("Expected a validation error, got %T", err)
+		}
+	}
+}
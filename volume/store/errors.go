@@ -14,6 +14,8 @@ var (
 	errInvalidName = errors.New("volume name is not valid on this platform")
 	// errNameConflict is a typed error returned on create when a volume exists with the given name, but for a different driver
 	errNameConflict = errors.New("conflict: volume name must be unique")
+	// errVolumeUnavailable is a typed error returned when trying to remove a volume whose driver is unavailable
+	errVolumeUnavailable = errors.New("volume driver is unavailable")
 )
 
 // OpErr is the error type returned by functions in the store package. It describes
@@ -57,12 +59,30 @@ func IsNotExist(err error) bool {
 	return isErr(err, errNoSuchVolume)
 }
 
+// IsUnavailable returns a boolean indicating whether the error indicates that
+// the driver of a volume is unavailable
+func IsUnavailable(err error) bool {
+	return isErr(err, errVolumeUnavailable)
+}
+
 // IsNameConflict returns a boolean indicating whether the error indicates that a
 // volume name is already taken
 func IsNameConflict(err error) bool {
 	return isErr(err, errNameConflict)
 }
 
+// IsValidationError returns a boolean indicating whether the error was
+// caused by invalid input, such as driver options rejected by the driver
+func IsValidationError(err error) bool {
+	if pe, ok := err.(*OpErr); ok {
+		err = pe.Err
+	}
+	_, ok := err.(interface {
+		IsValidationError() bool
+	})
+	return ok
+}
+
 func isErr(err error, expected error) bool {
 	switch pe := err.(type) {
 	case nil:
@@ -0,0 +1,209 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/boltdb/bolt"
+	"github.com/docker/docker/volume"
+	"github.com/docker/docker/volume/drivers"
+)
+
+// reconcileInterval is the time between two attempts to get the volumes
+// marked unavailable from their driver.
+var reconcileInterval = 30 * time.Second
+
+// EventLogger logs an event about the volume with the given name.
+type EventLogger func(name, action string, attributes map[string]string)
+
+// restoredVolume stands in for a volume recorded in the metadata until
+// it is returned by its driver. It keeps the driver of the volume known
+// when the driver is slow or missing at startup, so that the volume is
+// not probed on all drivers and its name cannot be taken by another one.
+type restoredVolume struct {
+	name       string
+	driverName string
+	// err is the error returned while getting the volume from its driver,
+	// which marks the volume as unavailable
+	err error
+}
+
+func (v restoredVolume) Name() string {
+	return v.name
+}
+
+func (v restoredVolume) DriverName() string {
+	return v.driverName
+}
+
+func (v restoredVolume) Path() string {
+	return ""
+}
+
+func (v restoredVolume) Mount(id string) (string, error) {
+	return "", v.unavailable()
+}
+
+func (v restoredVolume) Unmount(id string) error {
+	return v.unavailable()
+}
+
+func (v restoredVolume) Status() map[string]interface{} {
+	status := map[string]interface{}{"State": "unavailable"}
+	if v.err != nil {
+		status["Error"] = v.err.Error()
+	}
+	return status
+}
+
+func (v restoredVolume) unavailable() error {
+	if v.err != nil {
+		return fmt.Errorf("volume %s is unavailable: %v", v.name, v.err)
+	}
+	return fmt.Errorf("volume %s is unavailable: driver %s has not returned it yet", v.name, v.driverName)
+}
+
+// restore loads the metadata of the volumes recorded in the database.
+func (s *VolumeStore) restore() error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(volumeBucketName)).ForEach(func(k, data []byte) error {
+			var meta volumeMetadata
+			if err := json.Unmarshal(data, &meta); err != nil {
+				logrus.Errorf("Error reading metadata of volume %s: %v", k, err)
+				return nil
+			}
+
+			name := string(k)
+			s.labels[name] = meta.Labels
+			s.options[name] = meta.Options
+			// the driver is not recorded for volumes created by older versions
+			if meta.Driver != "" {
+				s.names[name] = restoredVolume{name: name, driverName: meta.Driver}
+			}
+			return nil
+		})
+	})
+}
+
+// Reconcile starts getting the volumes restored from the metadata from
+// their drivers in the background. Volumes whose driver is missing or
+// fails to return them are marked unavailable instead of being forgotten,
+// and are retried periodically. Volumes that the driver does not list
+// anymore are removed. logEvent is called when a volume is
+// marked unavailable or removed, and when its driver returns it again.
+func (s *VolumeStore) Reconcile(logEvent EventLogger) {
+	s.globalLock.Lock()
+	s.logEvent = logEvent
+	s.globalLock.Unlock()
+
+	go func() {
+		for s.reconcile() > 0 {
+			time.Sleep(reconcileInterval)
+		}
+	}()
+}
+
+// reconcile gets the restored volumes from their drivers, and returns the
+// number of volumes that are still unavailable.
+func (s *VolumeStore) reconcile() int {
+	byDriver := make(map[string][]string)
+	s.globalLock.RLock()
+	for name, v := range s.names {
+		if _, restored := v.(restoredVolume); restored {
+			byDriver[v.DriverName()] = append(byDriver[v.DriverName()], name)
+		}
+	}
+	s.globalLock.RUnlock()
+
+	var (
+		wg          sync.WaitGroup
+		mu          sync.Mutex
+		unavailable int
+	)
+	for driverName, names := range byDriver {
+		wg.Add(1)
+		go func(driverName string, names []string) {
+			defer wg.Done()
+			// a missing driver is only looked up once for all its volumes
+			vd, err := volumedrivers.GetDriver(driverName)
+			for _, name := range names {
+				if !s.reconcileVolume(name, vd, err) {
+					mu.Lock()
+					unavailable++
+					mu.Unlock()
+				}
+			}
+		}(driverName, names)
+	}
+	wg.Wait()
+	return unavailable
+}
+
+// reconcileVolume gets a restored volume from its driver, unless
+// getting the driver failed with driverErr. It returns false if the
+// volume is unavailable.
+func (s *VolumeStore) reconcileVolume(name string, vd volume.Driver, driverErr error) bool {
+	s.locks.Lock(name)
+	defer s.locks.Unlock(name)
+
+	// the volume may have been resolved or removed in the meantime
+	v, exists := s.getNamed(name)
+	rv, restored := v.(restoredVolume)
+	if !exists || !restored {
+		return true
+	}
+
+	err := driverErr
+	if err == nil {
+		var vol volume.Volume
+		if vol, err = vd.Get(name); err == nil {
+			s.setNamed(vol, "")
+			return true
+		}
+		if isMissingFromDriver(vd, name) {
+			logrus.Warnf("Removing volume %s which no longer exists in driver %s", name, rv.driverName)
+			s.purge(name)
+			s.globalLock.RLock()
+			logEvent := s.logEvent
+			s.globalLock.RUnlock()
+			if logEvent != nil {
+				logEvent(name, "destroy", map[string]string{"driver": rv.driverName})
+			}
+			return true
+		}
+	}
+
+	marked := rv.err != nil
+	rv.err = err
+	s.globalLock.Lock()
+	s.names[name] = rv
+	logEvent := s.logEvent
+	s.globalLock.Unlock()
+
+	if !marked {
+		logrus.Warnf("Volume %s is unavailable: %v", name, err)
+		if logEvent != nil {
+			logEvent(name, "unavailable", map[string]string{"driver": rv.driverName})
+		}
+	}
+	return false
+}
+
+// isMissingFromDriver returns whether the driver successfully lists its
+// volumes without the volume with the given name. The errors of plugins
+// only carry a message, which cannot tell that the volume does not exist.
+func isMissingFromDriver(vd volume.Driver, name string) bool {
+	ls, err := vd.List()
+	if err != nil {
+		return false
+	}
+	for _, v := range ls {
+		if v.Name() == name {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,276 @@
+package store
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/volume"
+	"github.com/docker/docker/volume/drivers"
+	vt "github.com/docker/docker/volume/testutils"
+)
+
+// unreachableDriver is a driver failing to get its volumes, like a plugin
+// which does not respond.
+type unreachableDriver struct {
+	volume.Driver
+}
+
+func (unreachableDriver) Get(name string) (volume.Volume, error) {
+	return nil, errors.New("connection refused")
+}
+
+func (unreachableDriver) List() ([]volume.Volume, error) {
+	return nil, errors.New("connection refused")
+}
+
+// notFoundDriver is a driver failing to get the volumes it lists, with an
+// error looking like the volume does not exist.
+type notFoundDriver struct {
+	volume.Driver
+}
+
+func (notFoundDriver) Get(name string) (volume.Volume, error) {
+	return nil, errors.New("mount point not found")
+}
+
+func TestRestoreAndReconcile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test-restore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	volumedrivers.Register(vt.NewFakeDriver("fake"), "fake")
+	s, err := New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	labels := map[string]string{"foo": "bar"}
+	opts := map[string]string{"replicas": "2"}
+	if _, err := s.Create("fake1", "fake", opts, labels); err != nil {
+		t.Fatal(err)
+	}
+	s.db.Close()
+	volumedrivers.Unregister("fake")
+
+	// the driver does not respond when the daemon starts
+	volumedrivers.Register(unreachableDriver{vt.NewFakeDriver("fake")}, "fake")
+	s, err = New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.db.Close()
+
+	v, exists := s.getNamed("fake1")
+	if !exists || v.DriverName() != "fake" {
+		t.Fatalf("Expected volume fake1 of driver fake to be restored, got %v", v)
+	}
+	if !reflect.DeepEqual(s.labels["fake1"], labels) || !reflect.DeepEqual(s.options["fake1"], opts) {
+		t.Fatalf("Expected labels and options to be restored, got %v and %v", s.labels["fake1"], s.options["fake1"])
+	}
+
+	var events []string
+	s.logEvent = func(name, action string, attributes map[string]string) {
+		events = append(events, name+" "+action)
+	}
+
+	if n := s.reconcile(); n != 1 {
+		t.Fatalf("Expected 1 unavailable volume, got %d", n)
+	}
+	v, _ = s.getNamed("fake1")
+	if v.Status()["State"] != "unavailable" {
+		t.Fatalf("Expected volume to be unavailable, got %v", v.Status())
+	}
+	if _, err := v.Mount("test"); err == nil {
+		t.Fatal("Expected mounting an unavailable volume to fail")
+	}
+	ls, _, err := s.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ls) != 1 || ls[0].Name() != "fake1" {
+		t.Fatalf("Expected unavailable volume to be listed, got %v", ls)
+	}
+	v, err = s.Get("fake1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Status()["State"] != "unavailable" {
+		t.Fatalf("Expected volume to be unavailable, got %v", v.Status())
+	}
+
+	// reconciling again does not log the volume as unavailable twice
+	s.reconcile()
+	if !reflect.DeepEqual(events, []string{"fake1 unavailable"}) {
+		t.Fatalf("Unexpected events: %v", events)
+	}
+
+	// the driver comes back with the volume
+	volumedrivers.Unregister("fake")
+	d := vt.NewFakeDriver("fake")
+	volumedrivers.Register(d, "fake")
+	defer volumedrivers.Unregister("fake")
+	if _, err := d.Create("fake1", nil); err != nil {
+		t.Fatal(err)
+	}
+	if n := s.reconcile(); n != 0 {
+		t.Fatalf("Expected no unavailable volume, got %d", n)
+	}
+	if !reflect.DeepEqual(events, []string{"fake1 unavailable", "fake1 available"}) {
+		t.Fatalf("Unexpected events: %v", events)
+	}
+
+	v, err = s.Get("fake1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dv, ok := v.(volume.DetailedVolume); !ok || !reflect.DeepEqual(dv.Options(), opts) {
+		t.Fatalf("Expected volume with options %v, got %v", opts, v)
+	}
+}
+
+func TestReconcileRemovesMissingVolume(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test-reconcile-missing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	volumedrivers.Register(vt.NewFakeDriver("fake"), "fake")
+	s, err := New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Create("fake1", "fake", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	s.db.Close()
+	volumedrivers.Unregister("fake")
+
+	// the driver comes back without the volume
+	volumedrivers.Register(vt.NewFakeDriver("fake"), "fake")
+	defer volumedrivers.Unregister("fake")
+
+	s, err = New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.db.Close()
+
+	var events []string
+	s.logEvent = func(name, action string, attributes map[string]string) {
+		events = append(events, name+" "+action)
+	}
+	if n := s.reconcile(); n != 0 {
+		t.Fatalf("Expected no unavailable volume, got %d", n)
+	}
+	if !reflect.DeepEqual(events, []string{"fake1 destroy"}) {
+		t.Fatalf("Unexpected events: %v", events)
+	}
+	if _, exists := s.getNamed("fake1"); exists {
+		t.Fatal("Expected volume missing from its driver to be removed")
+	}
+	if _, err := s.Create("fake1", "fake", nil, nil); err != nil {
+		t.Fatalf("Expected the name of the removed volume to be available, got %v", err)
+	}
+}
+
+func TestReconcileKeepsListedVolume(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test-reconcile-listed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	d := vt.NewFakeDriver("fake")
+	volumedrivers.Register(d, "fake")
+	s, err := New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Create("fake1", "fake", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	s.db.Close()
+	volumedrivers.Unregister("fake")
+
+	// the driver still lists the volume but fails to get it
+	volumedrivers.Register(notFoundDriver{d}, "fake")
+	defer volumedrivers.Unregister("fake")
+	s, err = New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.db.Close()
+
+	if n := s.reconcile(); n != 1 {
+		t.Fatalf("Expected 1 unavailable volume, got %d", n)
+	}
+	if _, exists := s.getNamed("fake1"); !exists {
+		t.Fatal("Expected volume listed by its driver not to be removed")
+	}
+}
+
+func TestRemoveUnavailableVolume(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test-remove-unavailable")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	volumedrivers.Register(vt.NewFakeDriver("fake"), "fake")
+	s, err := New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Create("fake1", "fake", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	s.db.Close()
+	volumedrivers.Unregister("fake")
+
+	// the driver of the volume does not respond
+	volumedrivers.Register(unreachableDriver{vt.NewFakeDriver("fake")}, "fake")
+	defer volumedrivers.Unregister("fake")
+	s, err = New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := s.reconcile(); n != 1 {
+		t.Fatalf("Expected 1 unavailable volume, got %d", n)
+	}
+
+	v, err := s.Get("fake1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Remove(v); !IsUnavailable(err) {
+		t.Fatalf("Expected unavailable volume not to be removed, got %v", err)
+	}
+	if n := s.reconcile(); n != 1 {
+		t.Fatalf("Expected 1 unavailable volume, got %d", n)
+	}
+	s.Purge("fake1")
+	if n := s.reconcile(); n != 0 {
+		t.Fatalf("Expected no unavailable volume, got %d", n)
+	}
+	s.db.Close()
+
+	// the removal is persisted
+	s, err = New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.db.Close()
+	if _, exists := s.getNamed("fake1"); exists {
+		t.Fatal("Expected removed volume not to be restored")
+	}
+	volumedrivers.Register(vt.NewFakeDriver("other"), "other")
+	defer volumedrivers.Unregister("other")
+	if _, err := s.Create("fake1", "other", nil, nil); err != nil {
+		t.Fatalf("Expected the name of the removed volume to be available, got %v", err)
+	}
+}
@@ -21,20 +21,28 @@ const (
 )
 
 type volumeMetadata struct {
-	Name   string
-	Labels map[string]string
+	Name     string
+	Driver   string
+	Labels   map[string]string
+	Options  map[string]string
+	LastUsed time.Time
 }
 
 type volumeWrapper struct {
 	volume.Volume
-	labels map[string]string
-	scope  string
+	labels  map[string]string
+	options map[string]string
+	scope   string
 }
 
 func (v volumeWrapper) Labels() map[string]string {
 	return v.labels
 }
 
+func (v volumeWrapper) Options() map[string]string {
+	return v.options
+}
+
 func (v volumeWrapper) Scope() string {
 	return v.scope
 }
@@ -52,10 +60,11 @@ func (v volumeWrapper) CachedPath() string {
 // reference counting of volumes in the system.
 func New(rootPath string) (*VolumeStore, error) {
 	vs := &VolumeStore{
-		locks:  &locker.Locker{},
-		names:  make(map[string]volume.Volume),
-		refs:   make(map[string][]string),
-		labels: make(map[string]map[string]string),
+		locks:   &locker.Locker{},
+		names:   make(map[string]volume.Volume),
+		refs:    make(map[string][]string),
+		labels:  make(map[string]map[string]string),
+		options: make(map[string]map[string]string),
 	}
 
 	if rootPath != "" {
@@ -83,6 +92,10 @@ func New(rootPath string) (*VolumeStore, error) {
 		}); err != nil {
 			return nil, err
 		}
+
+		if err := vs.restore(); err != nil {
+			return nil, err
+		}
 	}
 
 	return vs, nil
@@ -97,11 +110,21 @@ func (s *VolumeStore) getNamed(name string) (volume.Volume, bool) {
 
 func (s *VolumeStore) setNamed(v volume.Volume, ref string) {
 	s.globalLock.Lock()
+	old, _ := s.names[v.Name()].(restoredVolume)
 	s.names[v.Name()] = v
 	if len(ref) > 0 {
 		s.refs[v.Name()] = append(s.refs[v.Name()], ref)
 	}
+	logEvent := s.logEvent
 	s.globalLock.Unlock()
+
+	// the driver of a volume marked unavailable returned it again
+	if _, restored := v.(restoredVolume); !restored && old.err != nil {
+		logrus.Infof("Volume %s is available again", v.Name())
+		if logEvent != nil {
+			logEvent(v.Name(), "available", map[string]string{"driver": v.DriverName()})
+		}
+	}
 }
 
 // getRefs gets the list of refs for a given name
@@ -118,6 +141,7 @@ func (s *VolumeStore) purge(name string) {
 	delete(s.names, name)
 	delete(s.refs, name)
 	delete(s.labels, name)
+	delete(s.options, name)
 	err := s.db.Update(func(tx *bolt.Tx) error {
 		b := tx.Bucket([]byte(volumeBucketName))
 		return b.Delete([]byte(name))
@@ -142,7 +166,11 @@ type VolumeStore struct {
 	refs map[string][]string
 	// labels stores volume labels for each volume
 	labels map[string]map[string]string
-	db     *bolt.DB
+	// options stores the options each volume was created with
+	options map[string]map[string]string
+	db      *bolt.DB
+	// logEvent logs events about the availability of volumes, see Reconcile
+	logEvent EventLogger
 }
 
 // List proxies to all registered volume drivers to get the full list of volumes
@@ -202,35 +230,44 @@ func (s *VolumeStore) list() ([]volume.Volume, []string, error) {
 			}
 			for i, v := range vs {
 				s.globalLock.RLock()
-				vs[i] = volumeWrapper{v, s.labels[v.Name()], d.Scope()}
+				vs[i] = volumeWrapper{v, s.labels[v.Name()], s.options[v.Name()], d.Scope()}
 				s.globalLock.RUnlock()
 			}
 
-			chVols <- vols{vols: vs}
+			chVols <- vols{vols: vs, driverName: d.Name()}
 		}(vd)
 	}
 
-	badDrivers := make(map[string]struct{})
+	listed := make(map[string]struct{})
+	seen := make(map[string]struct{})
 	for i := 0; i < len(drivers); i++ {
 		vs := <-chVols
 
 		if vs.err != nil {
 			warnings = append(warnings, vs.err.Error())
-			badDrivers[vs.driverName] = struct{}{}
 			logrus.Warn(vs.err)
+			continue
+		}
+		listed[vs.driverName] = struct{}{}
+		for _, v := range vs.vols {
+			seen[v.Name()] = struct{}{}
 		}
 		ls = append(ls, vs.vols...)
 	}
 
-	if len(badDrivers) > 0 {
-		s.globalLock.RLock()
-		for _, v := range s.names {
-			if _, exists := badDrivers[v.DriverName()]; exists {
-				ls = append(ls, v)
-			}
+	// include the known volumes of drivers which failed to list their
+	// volumes or are not available at all, and the unavailable volumes
+	s.globalLock.RLock()
+	for name, v := range s.names {
+		if _, exists := seen[name]; exists {
+			continue
+		}
+		_, restored := v.(restoredVolume)
+		if _, exists := listed[v.DriverName()]; !exists || restored {
+			ls = append(ls, v)
 		}
-		s.globalLock.RUnlock()
 	}
+	s.globalLock.RUnlock()
 	return ls, warnings, nil
 }
 
@@ -283,7 +320,11 @@ func (s *VolumeStore) create(name, driverName string, opts, labels map[string]st
 		if v.DriverName() != driverName && driverName != "" && driverName != volume.DefaultDriverName {
 			return nil, errNameConflict
 		}
-		return v, nil
+		// a restored volume is only known by name, get it from its driver
+		if _, restored := v.(restoredVolume); !restored {
+			return v, nil
+		}
+		driverName = v.DriverName()
 	}
 
 	// Since there isn't a specified driver name, let's see if any of the existing drivers have this volume name
@@ -305,18 +346,28 @@ func (s *VolumeStore) create(name, driverName string, opts, labels map[string]st
 	if v, _ := vd.Get(name); v != nil {
 		return v, nil
 	}
+	if od, ok := vd.(volume.OptionsDescriber); ok {
+		if schema := od.OptionsSchema(); schema != nil {
+			if err := volume.ValidateOpts(schema, opts); err != nil {
+				return nil, err
+			}
+		}
+	}
 	v, err := vd.Create(name, opts)
 	if err != nil {
 		return nil, err
 	}
 	s.globalLock.Lock()
 	s.labels[name] = labels
+	s.options[name] = opts
 	s.globalLock.Unlock()
 
 	if s.db != nil {
 		metadata := &volumeMetadata{
-			Name:   name,
-			Labels: labels,
+			Name:    name,
+			Driver:  vd.Name(),
+			Labels:  labels,
+			Options: opts,
 		}
 
 		volData, err := json.Marshal(metadata)
@@ -333,7 +384,7 @@ func (s *VolumeStore) create(name, driverName string, opts, labels map[string]st
 		}
 	}
 
-	return volumeWrapper{v, labels, vd.Scope()}, nil
+	return volumeWrapper{v, labels, opts, vd.Scope()}, nil
 }
 
 // GetWithRef gets a volume with the given name from the passed in driver and stores the ref
@@ -358,7 +409,7 @@ func (s *VolumeStore) GetWithRef(name, driverName, ref string) (volume.Volume, e
 
 	s.globalLock.RLock()
 	defer s.globalLock.RUnlock()
-	return volumeWrapper{v, s.labels[name], vd.Scope()}, nil
+	return volumeWrapper{v, s.labels[name], s.options[name], vd.Scope()}, nil
 }
 
 // Get looks if a volume with the given name exists and returns it if so
@@ -380,6 +431,7 @@ func (s *VolumeStore) Get(name string) (volume.Volume, error) {
 // it is expected that callers of this function hold any necessary locks
 func (s *VolumeStore) getVolume(name string) (volume.Volume, error) {
 	labels := map[string]string{}
+	var options map[string]string
 
 	if s.db != nil {
 		// get meta
@@ -398,6 +450,7 @@ func (s *VolumeStore) getVolume(name string) (volume.Volume, error) {
 				return err
 			}
 			labels = meta.Labels
+			options = meta.Options
 
 			return nil
 		}); err != nil {
@@ -411,14 +464,17 @@ func (s *VolumeStore) getVolume(name string) (volume.Volume, error) {
 	s.globalLock.RUnlock()
 	if exists {
 		vd, err := volumedrivers.GetDriver(v.DriverName())
-		if err != nil {
-			return nil, err
+		if err == nil {
+			var vol volume.Volume
+			if vol, err = vd.Get(name); err == nil {
+				return volumeWrapper{vol, labels, options, vd.Scope()}, nil
+			}
 		}
-		vol, err := vd.Get(name)
-		if err != nil {
-			return nil, err
+		// keep returning a restored volume until its driver is available
+		if _, restored := v.(restoredVolume); restored {
+			return v, nil
 		}
-		return volumeWrapper{vol, labels, vd.Scope()}, nil
+		return nil, err
 	}
 
 	logrus.Debugf("Probing all drivers for volume with name: %s", name)
@@ -433,7 +489,7 @@ func (s *VolumeStore) getVolume(name string) (volume.Volume, error) {
 			continue
 		}
 
-		return volumeWrapper{v, labels, d.Scope()}, nil
+		return volumeWrapper{v, labels, options, d.Scope()}, nil
 	}
 	return nil, errNoSuchVolume
 }
@@ -449,9 +505,23 @@ func (s *VolumeStore) Remove(v volume.Volume) error {
 		return &OpErr{Err: errVolumeInUse, Name: v.Name(), Op: "remove", Refs: refs}
 	}
 
+	// the volume may have been returned by its driver since it was looked up
+	if cur, exists := s.getNamed(name); exists {
+		v = cur
+	}
+
 	vd, err := volumedrivers.GetDriver(v.DriverName())
+	if rv, restored := v.(restoredVolume); restored {
+		if err == nil {
+			v, err = vd.Get(name)
+		}
+		if err != nil {
+			logrus.Debugf("Volume %s is unavailable in driver %s: %v", name, rv.driverName, err)
+			return &OpErr{Err: errVolumeUnavailable, Name: name, Op: "remove"}
+		}
+	}
 	if err != nil {
-		return &OpErr{Err: err, Name: vd.Name(), Op: "remove"}
+		return &OpErr{Err: err, Name: v.DriverName(), Op: "remove"}
 	}
 
 	logrus.Debugf("Removing volume reference: driver %s, name %s", v.DriverName(), name)
@@ -464,6 +534,16 @@ func (s *VolumeStore) Remove(v volume.Volume) error {
 	return nil
 }
 
+// Purge removes the volume with the given name from the store without
+// removing it from its driver, which keeps its data. It is used to forget
+// volumes whose driver is unavailable.
+func (s *VolumeStore) Purge(name string) {
+	name = normaliseVolumeName(name)
+	s.locks.Lock(name)
+	s.purge(name)
+	s.locks.Unlock(name)
+}
+
 // Dereference removes the specified reference to the volume
 func (s *VolumeStore) Dereference(v volume.Volume, ref string) {
 	s.locks.Lock(v.Name())
@@ -492,6 +572,55 @@ func (s *VolumeStore) Refs(v volume.Volume) []string {
 	return refsOut
 }
 
+// SetLastUsed records the time at which the volume was last mounted by a
+// container.
+func (s *VolumeStore) SetLastUsed(v volume.Volume, t time.Time) error {
+	if s.db == nil {
+		return nil
+	}
+
+	name := v.Name()
+	s.locks.Lock(name)
+	defer s.locks.Unlock(name)
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(volumeBucketName))
+		meta := volumeMetadata{Name: name}
+		if data := b.Get([]byte(name)); len(data) > 0 {
+			if err := json.Unmarshal(data, &meta); err != nil {
+				return err
+			}
+		}
+		meta.LastUsed = t
+
+		data, err := json.Marshal(meta)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(name), data)
+	})
+}
+
+// LastUsed returns the time at which the volume was last mounted by a
+// container, or the zero time if it is not known.
+func (s *VolumeStore) LastUsed(v volume.Volume) time.Time {
+	var meta volumeMetadata
+	if s.db == nil {
+		return meta.LastUsed
+	}
+
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket([]byte(volumeBucketName)).Get([]byte(v.Name()))
+		if len(data) == 0 {
+			return nil
+		}
+		return json.Unmarshal(data, &meta)
+	}); err != nil {
+		logrus.Errorf("Error reading volume metadata: %v", err)
+	}
+	return meta.LastUsed
+}
+
 // FilterByDriver returns the available volumes filtered by driver name
 func (s *VolumeStore) FilterByDriver(name string) ([]volume.Volume, error) {
 	vd, err := volumedrivers.GetDriver(name)
@@ -504,7 +633,7 @@ func (s *VolumeStore) FilterByDriver(name string) ([]volume.Volume, error) {
 	}
 	s.globalLock.RLock()
 	for i, v := range ls {
-		ls[i] = volumeWrapper{v, s.labels[v.Name()], vd.Scope()}
+		ls[i] = volumeWrapper{v, s.labels[v.Name()], s.options[v.Name()], vd.Scope()}
 	}
 	s.globalLock.RUnlock()
 	return ls, nil
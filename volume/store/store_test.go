@@ -6,7 +6,9 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/docker/docker/volume"
 	"github.com/docker/docker/volume/drivers"
 	vt "github.com/docker/docker/volume/testutils"
 )
@@ -40,6 +42,41 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+// schemaDriver is a fake driver describing the options it accepts.
+type schemaDriver struct {
+	volume.Driver
+}
+
+func (schemaDriver) OptionsSchema() []volume.OptionSpec {
+	return []volume.OptionSpec{{Name: "replicas", Type: volume.OptionTypeInt, Required: true}}
+}
+
+func TestCreateValidatesOpts(t *testing.T) {
+	volumedrivers.Register(schemaDriver{vt.NewFakeDriver("schema")}, "schema")
+	defer volumedrivers.Unregister("schema")
+	s, err := New("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = s.Create("fake1", "schema", nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "missing required option") {
+		t.Fatalf("Expected missing option error, got %v", err)
+	}
+	if !IsValidationError(err) {
+		t.Fatalf("Expected a validation error, got %v", err)
+	}
+	if _, err := s.Create("fake1", "schema", map[string]string{"replicas": "two"}, nil); err == nil || !strings.Contains(err.Error(), "invalid value") {
+		t.Fatalf("Expected invalid value error, got %v", err)
+	}
+	if l, _, _ := s.List(); len(l) != 0 {
+		t.Fatalf("Expected no volume in the store, got %v", l)
+	}
+	if _, err := s.Create("fake1", "schema", map[string]string{"replicas": "2"}, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestRemove(t *testing.T) {
 	volumedrivers.Register(vt.NewFakeDriver("fake"), "fake")
 	volumedrivers.Register(vt.NewFakeDriver("noop"), "noop")
@@ -213,3 +250,43 @@ func TestDerefMultipleOfSameRef(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestLastUsed(t *testing.T) {
+	volumedrivers.Register(vt.NewFakeDriver("fake"), "fake")
+	defer volumedrivers.Unregister("fake")
+
+	dir, err := ioutil.TempDir("", "test-last-used")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s, err := New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := s.Create("fake1", "fake", nil, map[string]string{"foo": "bar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastUsed := s.LastUsed(v); !lastUsed.IsZero() {
+		t.Fatalf("expected unused volume to have no last used time, got %v", lastUsed)
+	}
+
+	now := time.Now().UTC()
+	if err := s.SetLastUsed(v, now); err != nil {
+		t.Fatal(err)
+	}
+	if lastUsed := s.LastUsed(v); !lastUsed.Equal(now) {
+		t.Fatalf("expected last used time %v, got %v", now, lastUsed)
+	}
+
+	v, err = s.Get("fake1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if labels := v.(volumeWrapper).Labels(); labels["foo"] != "bar" {
+		t.Fatalf("expected labels to be preserved, got %v", labels)
+	}
+}
@@ -1,6 +1,7 @@
 package volume
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -22,6 +23,11 @@ const (
 	GlobalScope = "global"
 )
 
+// ErrNotSupported is returned by the methods of the optional driver
+// interfaces, such as HealthChecker or Resizer, when the driver implements
+// the interface but turns out not to support the operation.
+var ErrNotSupported = errors.New("operation not supported by the volume driver")
+
 // Driver is for creating and removing volumes.
 type Driver interface {
 	// Name returns the name of the volume driver.
@@ -39,6 +45,40 @@ type Driver interface {
 	Scope() string
 }
 
+// Cloner is implemented by drivers that can clone the data of their
+// volumes directly. Volumes of drivers that do not implement it are
+// mounted and copied instead.
+type Cloner interface {
+	// Clone copies the data of src into dst. Both volumes belong to the
+	// driver and dst is empty.
+	Clone(src, dst Volume) error
+}
+
+// HealthChecker is implemented by drivers that can report whether they
+// are able to serve requests.
+type HealthChecker interface {
+	// Health returns an error describing why the driver is unhealthy,
+	// ErrNotSupported if it does not report its health, or nil if it is
+	// healthy.
+	Health() error
+}
+
+// Resizer is implemented by drivers that can change the size of their
+// volumes.
+type Resizer interface {
+	// Resize sets the size of the volume, in bytes.
+	Resize(vol Volume, size int64) error
+}
+
+// OptionsDescriber is implemented by drivers that describe the options
+// they accept when creating a volume. The options are validated against
+// this schema before the driver is asked to create the volume.
+type OptionsDescriber interface {
+	// OptionsSchema returns the options accepted by the driver, or nil if
+	// the driver does not describe its options.
+	OptionsSchema() []OptionSpec
+}
+
 // Capability defines a set of capabilities that a driver is able to handle.
 type Capability struct {
 	// Scope is the scope of the driver, `global` or `local`
@@ -46,6 +86,16 @@ type Capability struct {
 	// A `local` scope indicates that the driver only manages volumes resources local to the host
 	// Scope is declared by the driver
 	Scope string
+	// Version is the version of the plugin protocol implemented by the
+	// driver. Version 2 drivers report their health and can describe
+	// their options and resize volumes.
+	Version int
+	// Options is the schema of the options accepted on create.
+	// It is only used by version 2 drivers.
+	Options []OptionSpec
+	// Resize indicates that the driver can resize its volumes.
+	// It is only used by version 2 drivers.
+	Resize bool
 }
 
 // Volume is a place to store data. It is backed by a specific driver, and can be mounted.
@@ -65,6 +115,12 @@ type Volume interface {
 	Status() map[string]interface{}
 }
 
+// DetailedVolume wraps a Volume with the driver options it was created with
+type DetailedVolume interface {
+	Options() map[string]string
+	Volume
+}
+
 // LabeledVolume wraps a Volume with user-defined labels
 type LabeledVolume interface {
 	Labels() map[string]string
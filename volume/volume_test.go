@@ -171,6 +171,8 @@ func TestParseMountSpecSplit(t *testing.T) {
 			{"name:/named2", "external", "/named2", "", "name", "external", true, false},
 			{"name:/named3:ro", "local", "/named3", "", "name", "local", false, false},
 			{"local/name:/tmp:rw", "", "/tmp", "", "local/name", "", true, false},
+			{"alpine:3.4:/image1", "image", "/image1", "", "alpine:3.4", "image", true, false},
+			{"localhost:5000/alpine:3.4:/image2:ro", "image", "/image2", "", "localhost:5000/alpine:3.4", "image", false, false},
 			{"/tmp:tmp", "", "", "", "", "", true, true},
 		}
 	}
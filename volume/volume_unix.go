@@ -36,6 +36,23 @@ func (m *MountPoint) HasResource(absolutePath string) bool {
 	return err == nil && relPath != ".." && !strings.HasPrefix(relPath, fmt.Sprintf("..%c", filepath.Separator))
 }
 
+// splitMountSpec splits the spec into its source, destination and mode
+// fields. The name of a volume may contain colons, such as the image
+// reference used as the name of an image volume, so the fields before the
+// first absolute path are kept together as the source.
+func splitMountSpec(spec string) []string {
+	arr := strings.Split(spec, ":")
+	if filepath.IsAbs(arr[0]) {
+		return arr
+	}
+	for i := 1; i < len(arr); i++ {
+		if filepath.IsAbs(arr[i]) {
+			return append([]string{strings.Join(arr[:i], ":")}, arr[i:]...)
+		}
+	}
+	return arr
+}
+
 // ParseMountSpec validates the configuration of mount information is valid.
 func ParseMountSpec(spec, volumeDriver string) (*MountPoint, error) {
 	spec = filepath.ToSlash(spec)
@@ -44,11 +61,7 @@ func ParseMountSpec(spec, volumeDriver string) (*MountPoint, error) {
 		RW:          true,
 		Propagation: DefaultPropagationMode,
 	}
-	if strings.Count(spec, ":") > 2 {
-		return nil, errInvalidSpec(spec)
-	}
-
-	arr := strings.SplitN(spec, ":", 3)
+	arr := splitMountSpec(spec)
 	if arr[0] == "" {
 		return nil, errInvalidSpec(spec)
 	}